@@ -0,0 +1,157 @@
+// cmd/cscli/main.go
+//
+// cscli gère les overrides locaux d'IntelDetector (internal/core/intel.go) :
+// un fichier JSON {"ip": ["1.2.3.4"], "face_hash": [...]} surveillé à chaud
+// par le serveur en cours d'exécution (voir IntelDetector.watchOverrides),
+// sur le modèle du cscli de CrowdSec pour ses décisions locales. Ne parle
+// jamais au serveur directement : les deux processus communiquent via ce
+// fichier.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"surveillance-core/internal/core"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	path := os.Getenv("CONFIG_FILE")
+	config, err := core.LoadConfig(core.WithConfigFile(path))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "chargement de la configuration: %v\n", err)
+		os.Exit(1)
+	}
+	overridesPath := config.Intelligence.OverridesPath
+	if overridesPath == "" {
+		overridesPath = "intel_overrides.json"
+	}
+
+	switch os.Args[1] {
+	case "list":
+		err = runList(overridesPath)
+	case "add":
+		if len(os.Args) != 4 {
+			usage()
+			os.Exit(1)
+		}
+		err = runAdd(overridesPath, os.Args[2], os.Args[3])
+	case "remove":
+		if len(os.Args) != 4 {
+			usage()
+			os.Exit(1)
+		}
+		err = runRemove(overridesPath, os.Args[2], os.Args[3])
+	default:
+		usage()
+		os.Exit(1)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cscli: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: cscli list | add <type> <value> | remove <type> <value>")
+	fmt.Fprintln(os.Stderr, "  type: ip, face_hash, license_plate, ... (la clé de Detection.Metadata comparée)")
+}
+
+func runList(overridesPath string) error {
+	overrides, err := readOverrides(overridesPath)
+	if err != nil {
+		return err
+	}
+
+	types := make([]string, 0, len(overrides))
+	for t := range overrides {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	for _, t := range types {
+		values := overrides[t]
+		sort.Strings(values)
+		for _, v := range values {
+			fmt.Printf("%s\t%s\n", t, v)
+		}
+	}
+	return nil
+}
+
+func runAdd(overridesPath, decisionType, value string) error {
+	overrides, err := readOverrides(overridesPath)
+	if err != nil {
+		return err
+	}
+
+	for _, existing := range overrides[decisionType] {
+		if existing == value {
+			return nil
+		}
+	}
+	overrides[decisionType] = append(overrides[decisionType], value)
+
+	return writeOverrides(overridesPath, overrides)
+}
+
+func runRemove(overridesPath, decisionType, value string) error {
+	overrides, err := readOverrides(overridesPath)
+	if err != nil {
+		return err
+	}
+
+	values := overrides[decisionType]
+	for i, existing := range values {
+		if existing == value {
+			overrides[decisionType] = append(values[:i], values[i+1:]...)
+			break
+		}
+	}
+
+	return writeOverrides(overridesPath, overrides)
+}
+
+func readOverrides(path string) (map[string][]string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string][]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lecture de %s: %w", path, err)
+	}
+
+	var overrides map[string][]string
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("décodage de %s: %w", path, err)
+	}
+	return overrides, nil
+}
+
+// writeOverrides écrit overrides dans un fichier temporaire puis le renomme
+// sur path : un rename atomique, ce que IntelDetector.watchOverrides attend
+// (voir son commentaire sur la surveillance du répertoire plutôt que du
+// fichier) plutôt que de réécrire path en place.
+func writeOverrides(path string, overrides map[string][]string) error {
+	data, err := json.MarshalIndent(overrides, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encodage des overrides: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("écriture de %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("renommage de %s vers %s: %w", tmp, path, err)
+	}
+	return nil
+}
@@ -6,10 +6,16 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	"surveillance-core/internal/api"
 	"surveillance-core/internal/core"
+	"surveillance-core/internal/eventstream"
+	"surveillance-core/internal/logging"
+	"surveillance-core/internal/metrics"
+	"surveillance-core/internal/notify"
+	"surveillance-core/internal/telemetry"
 	"surveillance-core/internal/vision"
 	wsHub "surveillance-core/internal/websocket"
 
@@ -21,23 +27,74 @@ type App struct {
 	EventProcessor core.EventProcessor
 	WSHub          *wsHub.Hub
 	AlertManager   core.AlertManager
+	// Notifiers reçoit chaque alerte via EventProcessor.SetAlertCallback et
+	// la répartit vers les backends de notification configurés (voir
+	// core.NotifierRegistry) ; son arrêt est drainé après l'arrêt du
+	// serveur HTTP et de l'EventProcessor.
+	Notifiers *core.NotifierRegistry
+	// RemoteTokenSecret signe les RemoteToken attachés aux appels gRPC vers
+	// les services vision distants des caméras fédérées (voir
+	// api.Handler.visionClientFor), repris de Config.Security.JWTSecret.
+	RemoteTokenSecret string
+	// ConfigManager détient la configuration vivante du processus et permet
+	// son rechargement à chaud (voir core.ConfigManager.Watch) quand
+	// CONFIG_FILE est défini.
+	ConfigManager *core.ConfigManager
+	// VisionHealthClient surveille la santé du service vision déclaré par
+	// Config.VisionService (healthchecks gRPC standard, indépendants de
+	// VisionClient qui peut être un backend RTSP/ONVIF/mock) et met
+	// EventProcessor en pause via SetVisionHealthy quand il est injoignable.
+	VisionHealthClient vision.Client
 }
 
 func main() {
-	// Load advanced config from environment or defaults
-	config, err := core.LoadConfig()
+	// Load advanced config from environment or defaults, surchargée par le
+	// fichier JSON/YAML pointé par CONFIG_FILE si défini (voir
+	// core.WithConfigFile).
+	configFile := os.Getenv("CONFIG_FILE")
+	config, err := core.LoadConfig(core.WithConfigFile(configFile))
 	if err != nil {
 		log.Fatalf("Config error: %v", err)
 	}
 
+	// Traces OpenTelemetry du pipeline d'événements (frame -> détecteurs ->
+	// dispatch d'alerte), no-op tant que Observability.OTLPEndpoint est vide.
+	shutdownTelemetry, err := telemetry.Init(context.Background(), config.Observability)
+	if err != nil {
+		log.Fatalf("Erreur initialisation OpenTelemetry: %v", err)
+	}
+
 	// Initialisation des composants
 	app := initializeApp(config)
+	app.ConfigManager = core.NewConfigManager(config, configFile)
+
+	if configFile != "" {
+		watchCtx, stopWatch := context.WithCancel(context.Background())
+		defer stopWatch()
+		go func() {
+			err := app.ConfigManager.Watch(watchCtx, func(updated *core.Config) {
+				logging.SetLevel(updated.Logging.Level)
+				log.Printf("Configuration rechargée depuis %s (log_level=%s, alert_retention=%s)",
+					configFile, updated.Logging.Level, updated.Alerts.Retention)
+			})
+			if err != nil {
+				log.Printf("⚠️ Erreur de surveillance de %s: %v", configFile, err)
+			}
+		}()
+	}
 
 	// Démarrage du Hub WebSocket
 	go app.WSHub.Run()
 
+	// Démarrage du serveur gRPC de flux d'événements
+	go func() {
+		if err := eventstream.Serve(config.EventStream.GRPCAddress, app.EventProcessor); err != nil {
+			log.Printf("Erreur serveur gRPC de flux d'événements: %v", err)
+		}
+	}()
+
 	// Démarrage du serveur HTTP
-	router := setupRouter(app)
+	router, apiHandler := setupRouter(app)
 
 	server := &http.Server{
 		Addr:    config.Server.Port, // Use nested field
@@ -65,30 +122,155 @@ func main() {
 	if err := server.Shutdown(ctx); err != nil {
 		log.Fatalf("Erreur arrêt serveur: %v", err)
 	}
+
+	// Arrêt des flux vision (local et caméras fédérées) et du nettoyage
+	// périodique des alertes, dans le même délai que le serveur HTTP.
+	if err := apiHandler.Close(ctx); err != nil {
+		log.Printf("⚠️ Erreur arrêt des clients vision distants: %v", err)
+	}
+	if err := app.VisionClient.Close(ctx); err != nil {
+		log.Printf("⚠️ Erreur arrêt du client vision: %v", err)
+	}
+	if err := app.VisionHealthClient.Close(ctx); err != nil {
+		log.Printf("⚠️ Erreur arrêt du client de healthcheck vision: %v", err)
+	}
+	// EventProcessor n'est arrêté qu'une fois les clients vision (local et
+	// fédérés) fermés ci-dessus, pour qu'aucune détection ne soit plus
+	// soumise pendant qu'il draine ses shards et sa file de dispatch
+	// d'alertes.
+	if err := app.EventProcessor.Close(ctx); err != nil {
+		log.Printf("⚠️ Erreur arrêt de l'EventProcessor: %v", err)
+	}
+	if err := app.AlertManager.Close(ctx); err != nil {
+		log.Printf("⚠️ Erreur arrêt de l'AlertManager: %v", err)
+	}
+	if err := app.Notifiers.Drain(ctx); err != nil {
+		log.Printf("⚠️ Erreur arrêt du registre de notifications: %v", err)
+	}
+	if err := shutdownTelemetry(ctx); err != nil {
+		log.Printf("⚠️ Erreur arrêt de l'export de traces OpenTelemetry: %v", err)
+	}
 }
 
 func initializeApp(config *core.Config) *App {
-	visionClient := vision.NewMockClient()
-	eventProcessor := core.NewEventProcessor()
-	alertManager := core.NewAlertManager(config.Alerts.Retention)
-	hub := wsHub.NewHub()
-
-	eventProcessor.SetAlertCallback(func(alert core.Alert) {
-		hub.Broadcast(wsHub.Message{
-			Type: "alert",
-			Data: alert,
-		})
+	// Logger structuré (rotation de fichiers + échantillonnage par caméra),
+	// partagé par EventProcessor et NotifierRegistry ; logging.Logger reste
+	// utilisé si la configuration fournie est invalide (ex: output=file sans
+	// chemin).
+	appLogger, err := logging.Build(loggingConfigFrom(config.Logging))
+	if err != nil {
+		log.Printf("⚠️ Configuration du logger structuré invalide, logger par défaut conservé: %v", err)
+		appLogger = logging.Logger
+	}
+
+	visionClient := vision.NewClient(&vision.ClientConfig{
+		Type: vision.ClientTypeAuto,
+		RTSP: vision.DefaultRTSPClientConfig(),
+	})
+	eventProcessor := core.NewEventProcessorWithConfig(core.EventProcessorConfig{
+		QueueSize:  config.EventProcessing.QueueSize,
+		DropPolicy: config.EventProcessing.DropPolicy,
 	})
+	eventProcessor.SetLogger(appLogger)
+	if config.Intelligence.Enabled {
+		eventProcessor.RegisterDetector("intel", core.NewIntelDetector(config.Intelligence))
+	}
+	alertManager, err := core.NewAlertManager(config.Alerts.Retention, config.Database)
+	if err != nil {
+		log.Fatalf("Erreur initialisation AlertManager: %v", err)
+	}
+	hub := wsHub.NewHub(config.WebSocket.ReplayBufferSize)
+
+	notifiers := core.NewNotifierRegistry(core.NotifierRegistryConfig{
+		ReportOnly:  config.Alerts.ReportOnly,
+		BatchWindow: config.Alerts.ThrottleInterval,
+		BatchSize:   config.Alerts.BatchSize,
+	})
+	notifiers.SetLogger(appLogger)
+
+	for _, channel := range config.Alerts.NotificationChannels {
+		if !channel.Enabled {
+			continue
+		}
+		notifier, err := notify.NewFromChannel(channel, hub, config.Alerts.AlertTemplatePath, config.Alerts.ReportTemplatePath)
+		if err != nil {
+			log.Printf("⚠️ Canal de notification %q ignoré: %v", channel.Name, err)
+			continue
+		}
+		notifiers.Register(notifier, channel.Filters, channel.Retry, channel.Breaker)
+	}
+
+	log.Printf("Using notifications: %s", strings.Join(notifiers.GetNames(), ", "))
+
+	alertSink := func(alert core.Alert) {
+		alertManager.AddAlert(alert)
+		notifiers.Dispatch(alert)
+	}
+	eventProcessor.SetAlertCallback(alertSink)
+
+	if sinkable, ok := visionClient.(interface{ SetAlertSink(func(core.Alert)) }); ok {
+		sinkable.SetAlertSink(alertSink)
+	}
+
+	// Client dédié aux healthchecks gRPC standard du service vision déclaré
+	// par Config.VisionService, distinct de visionClient qui peut capturer
+	// les flux caméra via un tout autre backend (RTSP, ONVIF, mock).
+	visionHealthOpts, err := vision.GRPCClientOptionsFromConfig(config.VisionService)
+	if err != nil {
+		log.Printf("⚠️ Configuration TLS du service vision invalide, healthchecks désactivés: %v", err)
+	} else {
+		visionHealthOpts.OnHealthChange = eventProcessor.SetVisionHealthy
+	}
+	visionHealthClient := vision.NewGRPCClient(config.VisionService.Address, visionHealthOpts)
 
 	return &App{
-		VisionClient:   visionClient,
-		EventProcessor: eventProcessor,
-		WSHub:          hub,
-		AlertManager:   alertManager,
+		VisionClient:       visionClient,
+		EventProcessor:     eventProcessor,
+		WSHub:              hub,
+		AlertManager:       alertManager,
+		Notifiers:          notifiers,
+		RemoteTokenSecret:  config.Security.JWTSecret,
+		VisionHealthClient: visionHealthClient,
+	}
+}
+
+// cameraLogSampleRate borne le nombre de lignes de log émises par caméra et
+// par seconde (voir logging.perCameraSampler), pour qu'une caméra qui
+// spamme les détections n'étouffe pas les logs des autres.
+const cameraLogSampleRate = 100
+
+// loggingConfigFrom convertit core.LoggingConfig en logging.Config : core
+// importe déjà internal/logging, donc ce dernier ne peut pas référencer
+// core.LoggingConfig sans cycle d'import.
+func loggingConfigFrom(cfg core.LoggingConfig) logging.Config {
+	return logging.Config{
+		Level:            cfg.Level,
+		Format:           cfg.Format,
+		Output:           cfg.Output,
+		File:             cfg.File,
+		MaxSize:          cfg.MaxSize,
+		MaxAge:           cfg.MaxAge,
+		MaxBackups:       cfg.MaxBackups,
+		Compress:         cfg.Compress,
+		CameraSampleRate: cameraLogSampleRate,
 	}
 }
 
-func setupRouter(app *App) *gin.Engine {
+// v1Sunset est la date annoncée de retrait de /api/v1, exposée via le header
+// Sunset (RFC 8594) le long de Deprecation, le temps que les clients migrent
+// vers /api/v2.
+const v1Sunset = "Thu, 31 Dec 2026 23:59:59 GMT"
+
+// deprecationWarning annonce aux clients de /api/v1 que ce groupe de routes
+// est déprécié au profit de /api/v2, suivant la convention Deprecation/Sunset
+// des API REST matures plutôt qu'un simple message dans la documentation.
+func deprecationWarning(c *gin.Context) {
+	c.Header("Deprecation", "true")
+	c.Header("Sunset", v1Sunset)
+	c.Next()
+}
+
+func setupRouter(app *App) (*gin.Engine, *api.Handler) {
 	router := gin.Default()
 
 	// CORS middleware
@@ -105,9 +287,10 @@ func setupRouter(app *App) *gin.Engine {
 	})
 
 	// API routes
-	apiHandler := api.NewHandler(app.VisionClient, app.EventProcessor, app.AlertManager)
+	apiHandler := api.NewHandler(app.VisionClient, app.EventProcessor, app.AlertManager, app.WSHub, app.RemoteTokenSecret, app.ConfigManager)
 
 	v1 := router.Group("/api/v1")
+	v1.Use(deprecationWarning)
 	{
 		v1.GET("/cameras", apiHandler.GetCameras)
 		v1.POST("/cameras", apiHandler.CreateCamera)
@@ -116,8 +299,25 @@ func setupRouter(app *App) *gin.Engine {
 		v1.PUT("/cameras/:id/stop", apiHandler.StopCamera)
 		v1.GET("/alerts", apiHandler.GetAlerts)
 		v1.GET("/health", apiHandler.Health)
+		v1.POST("/detectors", apiHandler.RegisterDetector)
+		v1.GET("/detectors", apiHandler.ListDetectors)
+		v1.DELETE("/detectors/:name", apiHandler.UnregisterDetector)
+		v1.POST("/rules", apiHandler.LoadRules)
+		v1.GET("/events/stream", apiHandler.StreamEvents)
+	}
+
+	v2 := router.Group("/api/v2")
+	{
+		v2.GET("/alerts", apiHandler.GetAlertsV2)
+		v2.GET("/alerts/stats", apiHandler.GetAlertStats)
+		v2.POST("/alerts/:id/ack", apiHandler.AcknowledgeAlert)
+		v2.POST("/alerts/ack", apiHandler.AcknowledgeAlertsBatch)
+		v2.GET("/config", apiHandler.GetConfig)
 	}
 
+	// Métriques Prometheus
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+
 	// WebSocket endpoint
 	wsHandler := wsHub.NewHandler(app.WSHub)
 	router.GET("/ws", func(c *gin.Context) {
@@ -128,5 +328,5 @@ func setupRouter(app *App) *gin.Engine {
 	router.Static("/static", "./web/static")
 	router.StaticFile("/", "./web/index.html")
 
-	return router
+	return router, apiHandler
 }
@@ -2,10 +2,14 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"surveillance-core/internal/core"
 	"surveillance-core/internal/vision"
+	wsHub "surveillance-core/internal/websocket"
 	"sync"
 	"time"
 
@@ -13,20 +17,59 @@ import (
 	"github.com/google/uuid"
 )
 
+// remoteTokenTTL borne la durée de vie d'un RemoteToken signé à la volée par
+// visionClientFor quand CameraConfig.RemoteToken n'en fournit pas un : assez
+// long pour couvrir un stream qui tourne en continu sans forcer de
+// resignature fréquente, assez court pour qu'un jeton qui fuite ne reste pas
+// valide indéfiniment.
+const remoteTokenTTL = 1 * time.Hour
+
+// remoteClientKey identifie un client gRPC distant mis en cache par
+// visionClientFor : l'adresse seule ne suffit pas puisque plusieurs caméras
+// fédérées peuvent partager le même service vision distant, chacune avec son
+// propre RemoteToken lié à son CameraID (voir remoteTokenClaims.CameraID) -
+// les mélanger ferait streamer une caméra sous l'identité d'une autre.
+type remoteClientKey struct {
+	address  string
+	cameraID string
+}
+
+// remoteClientEntry associe un client gRPC distant mis en cache à
+// l'expiration du RemoteToken qu'il porte, pour que visionClientFor le
+// recrée avec un jeton frais plutôt que de continuer à appeler avec un
+// RemoteToken expiré (voir remoteTokenTTL).
+type remoteClientEntry struct {
+	client    vision.Client
+	expiresAt time.Time
+}
+
 type Handler struct {
-	visionClient   vision.Client
-	eventProcessor core.EventProcessor
-	alertManager   core.AlertManager
-	cameras        map[string]*core.Camera
-	mutex          sync.RWMutex
+	visionClient      vision.Client
+	eventProcessor    core.EventProcessor
+	alertManager      core.AlertManager
+	hub               *wsHub.Hub
+	pluginLoader      *core.PluginLoader
+	frameAnalyzer     *vision.FrameAnalyzer
+	cameras           map[string]*core.Camera
+	mutex             sync.RWMutex
+	remoteTokenSecret string
+	remoteClients     map[remoteClientKey]*remoteClientEntry
+	remoteMutex       sync.Mutex
+	configManager     *core.ConfigManager
 }
 
-func NewHandler(visionClient vision.Client, eventProcessor core.EventProcessor, alertManager core.AlertManager) *Handler {
+func NewHandler(visionClient vision.Client, eventProcessor core.EventProcessor, alertManager core.AlertManager, hub *wsHub.Hub, remoteTokenSecret string, configManager *core.ConfigManager) *Handler {
 	handler := &Handler{
-		visionClient:   visionClient,
-		eventProcessor: eventProcessor,
-		alertManager:   alertManager,
-		cameras:        make(map[string]*core.Camera),
+		visionClient:      visionClient,
+		eventProcessor:    eventProcessor,
+		alertManager:      alertManager,
+		hub:               hub,
+		pluginLoader:      core.NewPluginLoader(eventProcessor),
+		frameAnalyzer:     vision.NewFrameAnalyzer(vision.DefaultAnalyzerConfig()),
+		cameras:           make(map[string]*core.Camera),
+		remoteTokenSecret: remoteTokenSecret,
+		remoteClients:     make(map[remoteClientKey]*remoteClientEntry),
+		configManager:     configManager,
 	}
 
 	// Ajouter quelques caméras par défaut pour le test
@@ -35,6 +78,43 @@ func NewHandler(visionClient vision.Client, eventProcessor core.EventProcessor,
 	return handler
 }
 
+// GetConfig expose la configuration effective du serveur, secrets masqués
+// (voir core.Config.Redacted), pour que les opérateurs puissent vérifier à
+// chaud le résultat du chargement par couches et des éventuels reloads
+// (core.ConfigManager.Watch) sans avoir à lire les fichiers/variables
+// d'environnement du processus.
+func (h *Handler) GetConfig(c *gin.Context) {
+	if h.configManager == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "aucune configuration exposée par ce serveur"})
+		return
+	}
+	c.JSON(http.StatusOK, h.configManager.Get().Redacted())
+}
+
+// Close arrête les clients gRPC distants créés paresseusement par
+// visionClientFor pour les caméras fédérées (CameraConfig.RemoteURL). Le
+// visionClient local n'est pas de son ressort : il appartient à App et est
+// fermé séparément (voir cmd/server/main.go).
+func (h *Handler) Close(ctx context.Context) error {
+	h.remoteMutex.Lock()
+	clients := make([]vision.Client, 0, len(h.remoteClients))
+	for _, entry := range h.remoteClients {
+		clients = append(clients, entry.client)
+	}
+	h.remoteMutex.Unlock()
+
+	var errs []error
+	for _, client := range clients {
+		if err := client.Close(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("arrêt des clients vision distants: %v", errs)
+	}
+	return nil
+}
+
 func (h *Handler) addDefaultCameras() {
 	cameras := []*core.Camera{
 		{
@@ -106,11 +186,28 @@ func (h *Handler) GetCamera(c *gin.Context) {
 		return
 	}
 
+	client, remote, err := h.visionClientFor(camera)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
 	// Ajouter le statut du stream
-	streamStatus := h.visionClient.GetStreamStatus(cameraID)
+	streamStatus := client.GetStreamStatus(cameraID)
+	backend := "local"
+	if remote {
+		backend = "remote"
+	}
 	response := gin.H{
-		"camera":        camera,
-		"stream_status": streamStatus,
+		"camera":         camera,
+		"stream_status":  streamStatus,
+		"vision_backend": backend,
+	}
+
+	if dropCounter, ok := client.(vision.FrameDropCounter); ok {
+		if dropped, hasStream := dropCounter.DroppedFrameCount(cameraID); hasStream {
+			response["dropped_frames"] = dropped
+		}
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -147,6 +244,103 @@ func (h *Handler) CreateCamera(c *gin.Context) {
 	c.JSON(http.StatusCreated, camera)
 }
 
+// remoteAddress retourne "host:port" pour une caméra fédérée vers un service
+// vision distant (CameraConfig.RemoteURL, ou Hostname/Port séparés), et ""
+// si la caméra doit utiliser le visionClient local de l'application.
+func remoteAddress(cfg core.CameraConfig) string {
+	if cfg.RemoteURL != "" {
+		return cfg.RemoteURL
+	}
+	if cfg.Hostname != "" && cfg.Port != 0 {
+		return fmt.Sprintf("%s:%d", cfg.Hostname, cfg.Port)
+	}
+	return ""
+}
+
+// visionClientFor choisit, pour camera, entre le visionClient local et un
+// grpcClient dédié vers son service vision distant (CameraConfig.RemoteURL /
+// Hostname+Port). Le client distant est créé paresseusement au premier appel
+// et mis en cache par (adresse, CameraID) - pas par adresse seule, puisque
+// plusieurs caméras fédérées partagent souvent le même service vision
+// distant mais portent chacune un RemoteToken lié à leur propre CameraID
+// (voir remoteClientKey) - authentifié par un RemoteToken HS256 : celui de
+// CameraConfig.RemoteToken s'il est renseigné, sinon un jeton signé à la
+// volée via h.remoteTokenSecret (voir vision.SignRemoteToken). Une entrée en
+// cache dont le RemoteToken a expiré est recréée avec un jeton frais plutôt
+// que réutilisée. remote indique si camera est servie par ce backend distant
+// plutôt que par h.visionClient.
+func (h *Handler) visionClientFor(camera *core.Camera) (client vision.Client, remote bool, err error) {
+	address := remoteAddress(camera.Config)
+	if address == "" {
+		return h.visionClient, false, nil
+	}
+
+	key := remoteClientKey{address: address, cameraID: camera.ID}
+
+	h.remoteMutex.Lock()
+	defer h.remoteMutex.Unlock()
+
+	if existing, ok := h.remoteClients[key]; ok {
+		if time.Now().Before(existing.expiresAt) {
+			return existing.client, true, nil
+		}
+		// Jeton expiré : on referme ce client en arrière-plan et on en
+		// recrée un avec un jeton frais ci-dessous plutôt que de continuer
+		// à l'utiliser, ce que le service vision distant rejetterait.
+		go existing.client.Close(context.Background())
+		delete(h.remoteClients, key)
+	}
+
+	token := camera.Config.RemoteToken
+	expiresAt := time.Now().Add(remoteTokenTTL)
+	if token == "" {
+		token, err = vision.SignRemoteToken(h.remoteTokenSecret, camera.ID, remoteTokenTTL)
+		if err != nil {
+			return nil, false, fmt.Errorf("signature du jeton distant pour la caméra %s: %w", camera.ID, err)
+		}
+	} else if exp, expErr := vision.RemoteTokenExpiry(token); expErr == nil {
+		expiresAt = exp
+	}
+
+	remoteClient, err := vision.NewRemoteGRPCClient(address, token, camera.Config.RemoteTLS)
+	if err != nil {
+		return nil, false, fmt.Errorf("client vision distant pour la caméra %s: %w", camera.ID, err)
+	}
+	h.remoteClients[key] = &remoteClientEntry{client: remoteClient, expiresAt: expiresAt}
+	return remoteClient, true, nil
+}
+
+// startVisionStream démarre la capture pour camera en passant son URL et ses
+// préférences de transport/codec au client vision choisi par
+// visionClientFor (local, ou distant si CameraConfig.RemoteURL/Hostname+Port
+// est renseigné) quand celui-ci les supporte (backends RTSP/ONVIF/
+// ClientFactory), via les interfaces optionnelles vision.TransportAwareClient
+// puis vision.URLAwareClient. Pour un backend qui n'implémente ni l'une ni
+// l'autre (ex. mock, gRPC), on retombe sur StartStream(cameraID) comme
+// auparavant. Le stream démarre avec context.Background(), pas le contexte
+// de la requête HTTP : il doit survivre à la réponse et n'est arrêté que par
+// StopCamera ou par l'arrêt de l'application (vision.Client.Close), jamais
+// par la fin de la requête qui l'a déclenché.
+func (h *Handler) startVisionStream(camera *core.Camera) (<-chan core.Frame, error) {
+	ctx := context.Background()
+
+	client, _, err := h.visionClientFor(camera)
+	if err != nil {
+		return nil, err
+	}
+
+	if transportAware, ok := client.(vision.TransportAwareClient); ok && camera.URL != "" {
+		return transportAware.StartStreamWithOptions(ctx, camera.ID, camera.URL, vision.StreamOptions{
+			Transport:      camera.Config.Transport,
+			PreferredCodec: vision.StreamCodec(camera.Config.PreferredCodec),
+		})
+	}
+	if urlAware, ok := client.(vision.URLAwareClient); ok && camera.URL != "" {
+		return urlAware.StartStreamWithURL(ctx, camera.ID, camera.URL)
+	}
+	return client.StartStream(ctx, camera.ID)
+}
+
 func (h *Handler) StartCamera(c *gin.Context) {
 	cameraID := c.Param("id")
 
@@ -169,7 +363,7 @@ func (h *Handler) StartCamera(c *gin.Context) {
 	h.mutex.Unlock()
 
 	// Démarrer le stream
-	framesChan, err := h.visionClient.StartStream(cameraID)
+	framesChan, err := h.startVisionStream(camera)
 	if err != nil {
 		h.mutex.Lock()
 		camera.Status = core.CameraStatusError
@@ -206,13 +400,18 @@ func (h *Handler) StopCamera(c *gin.Context) {
 	}
 	h.mutex.Unlock()
 
-	// Arrêter le stream
-	err := h.visionClient.StopStream(cameraID)
+	client, _, err := h.visionClientFor(camera)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur arrêt stream"})
 		return
 	}
 
+	// Arrêter le stream
+	if err := client.StopStream(cameraID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erreur arrêt stream"})
+		return
+	}
+
 	h.mutex.Lock()
 	camera.Status = core.CameraStatusOffline
 	h.mutex.Unlock()
@@ -225,8 +424,10 @@ func (h *Handler) StopCamera(c *gin.Context) {
 
 // Endpoint alertes
 
+// GetAlerts est le handler v1 historique : pagination limit/offset et
+// filtrage exact par camera_id uniquement. Conservé tel quel pour les
+// clients v1 existants ; voir GetAlertsV2 pour le filtrage enrichi.
 func (h *Handler) GetAlerts(c *gin.Context) {
-	// Paramètres de pagination
 	limitStr := c.DefaultQuery("limit", "50")
 	offsetStr := c.DefaultQuery("offset", "0")
 	cameraID := c.Query("camera_id")
@@ -254,6 +455,259 @@ func (h *Handler) GetAlerts(c *gin.Context) {
 	})
 }
 
+// alertQueryFilterFromRequest construit un core.AlertQueryFilter à partir
+// des paramètres de requête v2 (level, type, camera_id, since, until,
+// acknowledged). Les paramètres absents ou invalides sont simplement
+// ignorés, sans erreur renvoyée au client.
+func alertQueryFilterFromRequest(c *gin.Context) core.AlertQueryFilter {
+	filter := core.AlertQueryFilter{
+		CameraID: c.Query("camera_id"),
+		Type:     core.AlertType(c.Query("type")),
+		Level:    core.AlertLevel(c.Query("level")),
+	}
+	if since := c.Query("since"); since != "" {
+		if t, err := time.Parse(time.RFC3339, since); err == nil {
+			filter.Since = t
+		}
+	}
+	if until := c.Query("until"); until != "" {
+		if t, err := time.Parse(time.RFC3339, until); err == nil {
+			filter.Until = t
+		}
+	}
+	if acked := c.Query("acknowledged"); acked != "" {
+		if b, err := strconv.ParseBool(acked); err == nil {
+			filter.Acknowledged = &b
+		}
+	}
+	return filter
+}
+
+// GetAlertsV2 étend GetAlerts avec le filtrage complet porté par
+// core.AlertQueryFilter (level, type, camera_id, since, until,
+// acknowledged), en plus de la pagination limit/offset.
+func (h *Handler) GetAlertsV2(c *gin.Context) {
+	limitStr := c.DefaultQuery("limit", "50")
+	offsetStr := c.DefaultQuery("offset", "0")
+
+	limit, _ := strconv.Atoi(limitStr)
+	offset, _ := strconv.Atoi(offsetStr)
+
+	filter := alertQueryFilterFromRequest(c)
+	alerts := h.alertManager.GetAlertsFiltered(filter, limit, offset)
+	stats := h.alertManager.GetAlertStats()
+
+	c.JSON(http.StatusOK, gin.H{
+		"alerts": alerts,
+		"stats":  stats,
+		"pagination": gin.H{
+			"limit":  limit,
+			"offset": offset,
+			"total":  stats.Total,
+		},
+	})
+}
+
+// GetAlertStats expose séparément core.AlertManager.GetAlertStats (v2
+// uniquement ; en v1, les stats sont renvoyées inline par GetAlerts).
+func (h *Handler) GetAlertStats(c *gin.Context) {
+	c.JSON(http.StatusOK, h.alertManager.GetAlertStats())
+}
+
+// AckAlertRequest est le corps attendu par AcknowledgeAlert.
+type AckAlertRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+}
+
+// AcknowledgeAlert acquitte une alerte et renvoie l'alerte mise à jour.
+func (h *Handler) AcknowledgeAlert(c *gin.Context) {
+	alertID := c.Param("id")
+
+	var req AckAlertRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.alertManager.AcknowledgeAlert(alertID, req.UserID); err != nil {
+		if err == core.ErrAlertNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	updated := h.alertManager.GetAlertsFiltered(core.AlertQueryFilter{ID: alertID}, 1, 0)
+	if len(updated) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": core.ErrAlertNotFound.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"alert": updated[0]})
+}
+
+// AckAlertsBatchRequest est le corps attendu par AcknowledgeAlertsBatch.
+type AckAlertsBatchRequest struct {
+	IDs    []string `json:"ids" binding:"required"`
+	UserID string   `json:"user_id" binding:"required"`
+}
+
+// AcknowledgeAlertsBatch acquitte plusieurs alertes en une requête et
+// renvoie, pour chaque ID, le succès ou l'erreur rencontrée.
+func (h *Handler) AcknowledgeAlertsBatch(c *gin.Context) {
+	var req AckAlertsBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	results := make([]gin.H, 0, len(req.IDs))
+	for _, alertID := range req.IDs {
+		if err := h.alertManager.AcknowledgeAlert(alertID, req.UserID); err != nil {
+			results = append(results, gin.H{"id": alertID, "ok": false, "error": err.Error()})
+			continue
+		}
+		results = append(results, gin.H{"id": alertID, "ok": true})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"results": results})
+}
+
+// Endpoints détecteurs
+
+// RegisterDetectorRequest décrit un plugin de détecteur à charger depuis le
+// répertoire plugins/ du serveur.
+type RegisterDetectorRequest struct {
+	Name string `json:"name" binding:"required"`
+	Path string `json:"path" binding:"required"`
+}
+
+// RegisterDetector charge dynamiquement un plugin de détecteur (fichier .so)
+// et l'enregistre auprès de l'EventProcessor.
+func (h *Handler) RegisterDetector(c *gin.Context) {
+	var req RegisterDetectorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.pluginLoader.LoadFile(req.Name, req.Path); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Détecteur enregistré", "name": req.Name})
+}
+
+// ListDetectors retourne les détecteurs actuellement enregistrés.
+func (h *Handler) ListDetectors(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"detectors": h.eventProcessor.ListDetectors()})
+}
+
+// UnregisterDetector retire un détecteur chargé dynamiquement.
+func (h *Handler) UnregisterDetector(c *gin.Context) {
+	name := c.Param("name")
+
+	if err := h.pluginLoader.Unload(name); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Détecteur désenregistré", "name": name})
+}
+
+// Endpoint règles
+
+// LoadRules recharge à chaud le moteur de règles d'alerte à partir du corps
+// de la requête (YAML ou JSON).
+func (h *Handler) LoadRules(c *gin.Context) {
+	if err := h.eventProcessor.LoadRules(c.Request.Body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Règles rechargées"})
+}
+
+// Endpoint flux d'événements
+
+// eventStreamHeartbeatInterval fixe la fréquence des lignes de heartbeat
+// NDJSON, pour que les proxys intermédiaires ne coupent pas une connexion
+// inactive entre deux événements.
+const eventStreamHeartbeatInterval = 15 * time.Second
+
+// StreamEvents expose le flux de détections/alertes en NDJSON (une ligne
+// JSON par événement) via une réponse chunked. Le header Last-Event-ID
+// permet à un client qui se reconnecte de rejouer les événements manqués
+// depuis le ring-buffer interne.
+func (h *Handler) StreamEvents(c *gin.Context) {
+	var filter core.EventFilter
+	filter.CameraID = c.Query("camera_id")
+	filter.AlertType = core.AlertType(c.Query("alert_type"))
+	filter.MinLevel = core.AlertLevel(c.Query("min_level"))
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+
+	flusher, ok := c.Writer.(http.Flusher)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "streaming non supporté"})
+		return
+	}
+
+	writeLine := func(v interface{}) bool {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return false
+		}
+		if _, err := c.Writer.Write(append(data, '\n')); err != nil {
+			return false
+		}
+		flusher.Flush()
+		return true
+	}
+
+	if lastEventID := c.GetHeader("Last-Event-ID"); lastEventID != "" {
+		if cursor, err := strconv.ParseInt(lastEventID, 10, 64); err == nil {
+			for _, event := range h.eventProcessor.ReplayEvents(cursor) {
+				if !writeLine(event) {
+					return
+				}
+			}
+		}
+	}
+
+	events, errs, cancel := h.eventProcessor.Subscribe(filter)
+	defer cancel()
+
+	heartbeat := time.NewTicker(eventStreamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case err, ok := <-errs:
+			if ok && err != nil {
+				writeLine(gin.H{"error": err.Error()})
+			}
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if !writeLine(event) {
+				return
+			}
+		case <-heartbeat.C:
+			if !writeLine(gin.H{"heartbeat": time.Now()}) {
+				return
+			}
+		}
+	}
+}
+
 // Endpoint santé
 
 func (h *Handler) Health(c *gin.Context) {
@@ -276,18 +730,46 @@ func (h *Handler) Health(c *gin.Context) {
 
 // Traitement des frames en arrière-plan
 
+// cameraTopic construit le topic WebSocket scopé à cameraID, sur lequel
+// Hub.BroadcastTo publie les métadonnées de frame (voir processFrames), afin
+// qu'un client n'abonné qu'à un sous-ensemble de caméras ne reçoive pas les
+// frames des autres.
+func cameraTopic(cameraID string) string {
+	return "camera:" + cameraID
+}
+
 func (h *Handler) processFrames(cameraID string, framesChan <-chan core.Frame) {
 	for frame := range framesChan {
-		// Mettre à jour timestamp dernière frame
+		// Mettre à jour timestamp dernière frame, et relever la config de
+		// détection courante sous le même verrou.
 		h.mutex.Lock()
-		if camera, exists := h.cameras[cameraID]; exists {
+		camera, exists := h.cameras[cameraID]
+		var enableMotion bool
+		var zones []core.Zone
+		if exists {
 			camera.LastFrame = &frame.Timestamp
+			enableMotion = camera.Config.EnableMotion
+			zones = camera.Config.Zones
 		}
 		h.mutex.Unlock()
 
-		// Ici on pourrait appeler l'EventProcessor avec des détections
-		// Pour le moment, on simule juste la réception des frames
-		
+		if enableMotion {
+			for _, detection := range h.frameAnalyzer.AnalyzeFrame(frame, zones) {
+				h.eventProcessor.ProcessDetection(detection)
+			}
+		}
+
+		h.hub.BroadcastTo(cameraTopic(cameraID), wsHub.Message{
+			Type: "frame",
+			Data: gin.H{
+				"camera_id": cameraID,
+				"width":     frame.Width,
+				"height":    frame.Height,
+				"size":      frame.Size,
+			},
+			Timestamp: frame.Timestamp,
+		})
+
 		// Log toutes les 5 secondes pour éviter le spam
 		if frame.Timestamp.Second()%5 == 0 {
 			println("📹 Frame reçue de", cameraID, "taille:", frame.Size, "bytes")
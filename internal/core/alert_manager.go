@@ -2,170 +2,147 @@
 package core
 
 import (
+	"context"
 	"fmt"
-	"log"
-	"sync"
 	"time"
+
+	"surveillance-core/internal/logging"
+	"surveillance-core/internal/metrics"
 )
 
 type AlertManager interface {
 	AddAlert(alert Alert)
 	GetAlerts(limit int, offset int) []Alert
+	// GetAlertsFiltered applique filter en plus de la pagination limit/offset
+	// (voir AlertQueryFilter pour les critères disponibles).
+	GetAlertsFiltered(filter AlertQueryFilter, limit, offset int) []Alert
 	GetAlertsByCamera(cameraID string) []Alert
 	AcknowledgeAlert(alertID, userID string) error
 	GetAlertStats() AlertStats
 	CleanupOldAlerts()
+	// Close arrête le nettoyage périodique en arrière-plan et attend sa fin,
+	// ou le retour de ctx. À appeler une seule fois, après server.Shutdown,
+	// voir cmd/server/main.go.
+	Close(ctx context.Context) error
 }
 
 type AlertStats struct {
-	Total        int `json:"total"`
-	Acknowledged int `json:"acknowledged"`
-	Pending      int `json:"pending"`
+	Total        int                `json:"total"`
+	Acknowledged int                `json:"acknowledged"`
+	Pending      int                `json:"pending"`
 	ByLevel      map[AlertLevel]int `json:"by_level"`
 	ByType       map[AlertType]int  `json:"by_type"`
 }
 
+// alertManager délègue toute la persistance à un AlertStore (mémoire, SQLite
+// ou Postgres selon DatabaseConfig) au lieu de garder les alertes dans un
+// slice maison. Voir alert_store.go.
 type alertManager struct {
-	alerts    []Alert
+	store     AlertStore
 	retention time.Duration
-	mutex     sync.RWMutex
+	cancel    context.CancelFunc
+	done      chan struct{}
 }
 
-func NewAlertManager(retention time.Duration) AlertManager {
+// NewAlertManager crée un AlertManager persistant dans le backend désigné
+// par dbConfig ("sqlite", "postgres", tout le reste retombant sur un store
+// en mémoire). Les alertes plus anciennes que retention sont purgées
+// périodiquement.
+func NewAlertManager(retention time.Duration, dbConfig DatabaseConfig) (AlertManager, error) {
+	store, err := NewAlertStore(dbConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
 	am := &alertManager{
-		alerts:    make([]Alert, 0),
+		store:     store,
 		retention: retention,
+		cancel:    cancel,
+		done:      make(chan struct{}),
 	}
-	
+
 	// Nettoyage périodique des anciennes alertes
-	go am.periodicCleanup()
-	
-	return am
+	go am.periodicCleanup(ctx)
+
+	return am, nil
 }
 
 func (am *alertManager) AddAlert(alert Alert) {
-	am.mutex.Lock()
-	defer am.mutex.Unlock()
-	
-	// Insertion triée par timestamp (plus récent en premier)
-	insertIndex := 0
-	for i, existingAlert := range am.alerts {
-		if alert.Timestamp.After(existingAlert.Timestamp) {
-			insertIndex = i
-			break
-		}
-		insertIndex = i + 1
+	start := time.Now()
+	err := am.store.Add(alert)
+	metrics.AlertAddDuration.Observe(time.Since(start).Seconds())
+	if err != nil {
+		logging.Logger.WithFields(logging.AlertFields(alert.ID)).WithError(err).Error("Erreur enregistrement alerte")
+		return
 	}
-	
-	// Insertion à l'index calculé
-	am.alerts = append(am.alerts, Alert{})
-	copy(am.alerts[insertIndex+1:], am.alerts[insertIndex:])
-	am.alerts[insertIndex] = alert
+	metrics.AlertsTotal.WithLabelValues(string(alert.Level), string(alert.Type)).Inc()
 }
 
 func (am *alertManager) GetAlerts(limit int, offset int) []Alert {
-	am.mutex.RLock()
-	defer am.mutex.RUnlock()
-	
-	if offset >= len(am.alerts) {
+	return am.GetAlertsFiltered(AlertQueryFilter{}, limit, offset)
+}
+
+func (am *alertManager) GetAlertsFiltered(filter AlertQueryFilter, limit, offset int) []Alert {
+	start := time.Now()
+	alerts, err := am.store.Query(filter, limit, offset)
+	metrics.AlertQueryDuration.Observe(time.Since(start).Seconds())
+	if err != nil {
+		logging.Logger.WithError(err).Error("Erreur lecture alertes")
 		return []Alert{}
 	}
-	
-	end := offset + limit
-	if end > len(am.alerts) {
-		end = len(am.alerts)
-	}
-	
-	// Copie pour éviter les races conditions
-	result := make([]Alert, end-offset)
-	copy(result, am.alerts[offset:end])
-	
-	return result
+	return alerts
 }
 
 func (am *alertManager) GetAlertsByCamera(cameraID string) []Alert {
-	am.mutex.RLock()
-	defer am.mutex.RUnlock()
-	
-	var result []Alert
-	for _, alert := range am.alerts {
-		if alert.CameraID == cameraID {
-			result = append(result, alert)
-		}
-	}
-	
-	return result
+	return am.GetAlertsFiltered(AlertQueryFilter{CameraID: cameraID}, 0, 0)
 }
 
 func (am *alertManager) AcknowledgeAlert(alertID, userID string) error {
-	am.mutex.Lock()
-	defer am.mutex.Unlock()
-	
-	for i := range am.alerts {
-		if am.alerts[i].ID == alertID {
-			now := time.Now()
-			am.alerts[i].Acknowledged = true
-			am.alerts[i].AckedBy = userID
-			am.alerts[i].AckedAt = &now
-			return nil
-		}
-	}
-	
-	return ErrAlertNotFound
+	return am.store.Ack(alertID, userID)
 }
 
 func (am *alertManager) GetAlertStats() AlertStats {
-	am.mutex.RLock()
-	defer am.mutex.RUnlock()
-	
-	stats := AlertStats{
-		Total:   len(am.alerts),
-		ByLevel: make(map[AlertLevel]int),
-		ByType:  make(map[AlertType]int),
-	}
-	
-	for _, alert := range am.alerts {
-		if alert.Acknowledged {
-			stats.Acknowledged++
-		} else {
-			stats.Pending++
-		}
-		
-		stats.ByLevel[alert.Level]++
-		stats.ByType[alert.Type]++
+	stats, err := am.store.Stats()
+	if err != nil {
+		logging.Logger.WithError(err).Error("Erreur calcul statistiques alertes")
+		return AlertStats{ByLevel: make(map[AlertLevel]int), ByType: make(map[AlertType]int)}
 	}
-	
+	metrics.AlertsPending.Set(float64(stats.Pending))
 	return stats
 }
 
 func (am *alertManager) CleanupOldAlerts() {
-	am.mutex.Lock()
-	defer am.mutex.Unlock()
-	
-	cutoff := time.Now().Add(-am.retention)
-	
-	// Filtrer les alertes récentes
-	filtered := make([]Alert, 0)
-	for _, alert := range am.alerts {
-		if alert.Timestamp.After(cutoff) {
-			filtered = append(filtered, alert)
-		}
-	}
-	
-	removed := len(am.alerts) - len(filtered)
-	am.alerts = filtered
-	
-	if removed > 0 {
-		log.Printf("Nettoyage: %d alertes supprimées", removed)
+	if err := am.store.Cleanup(am.retention); err != nil {
+		logging.Logger.WithError(err).Error("Erreur nettoyage des anciennes alertes")
 	}
 }
 
-func (am *alertManager) periodicCleanup() {
+func (am *alertManager) periodicCleanup(ctx context.Context) {
+	defer close(am.done)
+
 	ticker := time.NewTicker(time.Hour)
 	defer ticker.Stop()
-	
-	for range ticker.C {
-		am.CleanupOldAlerts()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			am.CleanupOldAlerts()
+		}
+	}
+}
+
+// Close arrête periodicCleanup et attend sa sortie, ou le retour de ctx.
+func (am *alertManager) Close(ctx context.Context) error {
+	am.cancel()
+
+	select {
+	case <-am.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("arrêt du nettoyage périodique des alertes non terminé avant expiration du délai: %w", ctx.Err())
 	}
 }
 
@@ -8,6 +8,15 @@ import (
 	"github.com/google/uuid"
 )
 
+func newTestAlertManager(tb testing.TB, retention time.Duration) AlertManager {
+	tb.Helper()
+	am, err := NewAlertManager(retention, DatabaseConfig{})
+	if err != nil {
+		tb.Fatalf("Unexpected error creating AlertManager: %v", err)
+	}
+	return am
+}
+
 func createTestAlert(cameraID string, level AlertLevel, ageMinutes int) Alert {
 	return Alert{
 		ID:        uuid.New().String(),
@@ -20,7 +29,7 @@ func createTestAlert(cameraID string, level AlertLevel, ageMinutes int) Alert {
 }
 
 func TestAlertManager_AddAlert(t *testing.T) {
-	am := NewAlertManager(time.Hour)
+	am := newTestAlertManager(t, time.Hour)
 
 	alert := createTestAlert("cam_001", AlertLevelWarning, 0)
 	am.AddAlert(alert)
@@ -36,7 +45,7 @@ func TestAlertManager_AddAlert(t *testing.T) {
 }
 
 func TestAlertManager_GetAlerts_Pagination(t *testing.T) {
-	am := NewAlertManager(time.Hour)
+	am := newTestAlertManager(t, time.Hour)
 
 	// Ajouter 15 alertes
 	for i := 0; i < 15; i++ {
@@ -67,7 +76,7 @@ func TestAlertManager_GetAlerts_Pagination(t *testing.T) {
 }
 
 func TestAlertManager_GetAlerts_SortedByTime(t *testing.T) {
-	am := NewAlertManager(time.Hour)
+	am := newTestAlertManager(t, time.Hour)
 
 	// Ajouter des alertes avec différents timestamps
 	alert1 := createTestAlert("cam_001", AlertLevelInfo, 10) // Plus ancienne
@@ -94,7 +103,7 @@ func TestAlertManager_GetAlerts_SortedByTime(t *testing.T) {
 }
 
 func TestAlertManager_GetAlertsByCamera(t *testing.T) {
-	am := NewAlertManager(time.Hour)
+	am := newTestAlertManager(t, time.Hour)
 
 	// Ajouter des alertes pour différentes caméras
 	alert1 := createTestAlert("cam_001", AlertLevelInfo, 0)
@@ -125,7 +134,7 @@ func TestAlertManager_GetAlertsByCamera(t *testing.T) {
 }
 
 func TestAlertManager_AcknowledgeAlert(t *testing.T) {
-	am := NewAlertManager(time.Hour)
+	am := newTestAlertManager(t, time.Hour)
 
 	alert := createTestAlert("cam_001", AlertLevelWarning, 0)
 	am.AddAlert(alert)
@@ -161,7 +170,7 @@ func TestAlertManager_AcknowledgeAlert(t *testing.T) {
 }
 
 func TestAlertManager_GetAlertStats(t *testing.T) {
-	am := NewAlertManager(time.Hour)
+	am := newTestAlertManager(t, time.Hour)
 
 	// Ajouter différents types d'alertes
 	alert1 := createTestAlert("cam_001", AlertLevelInfo, 0)
@@ -210,7 +219,7 @@ func TestAlertManager_GetAlertStats(t *testing.T) {
 
 func TestAlertManager_CleanupOldAlerts(t *testing.T) {
 	// Rétention courte pour le test
-	am := NewAlertManager(time.Minute)
+	am := newTestAlertManager(t, time.Minute)
 
 	// Ajouter des alertes anciennes et récentes
 	oldAlert := createTestAlert("cam_001", AlertLevelInfo, 5)       // 5 minutes (devrait être supprimée)
@@ -234,7 +243,7 @@ func TestAlertManager_CleanupOldAlerts(t *testing.T) {
 }
 
 func TestAlertManager_Concurrency(t *testing.T) {
-	am := NewAlertManager(time.Hour)
+	am := newTestAlertManager(t, time.Hour)
 
 	// Lancer plusieurs goroutines qui ajoutent des alertes
 	done := make(chan bool, 10)
@@ -261,7 +270,7 @@ func TestAlertManager_Concurrency(t *testing.T) {
 
 // Benchmark pour les performances
 func BenchmarkAlertManager_AddAlert(b *testing.B) {
-	am := NewAlertManager(time.Hour)
+	am := newTestAlertManager(b, time.Hour)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -271,7 +280,7 @@ func BenchmarkAlertManager_AddAlert(b *testing.B) {
 }
 
 func BenchmarkAlertManager_GetAlerts(b *testing.B) {
-	am := NewAlertManager(time.Hour)
+	am := newTestAlertManager(b, time.Hour)
 
 	// Préparer des données
 	for i := 0; i < 1000; i++ {
@@ -0,0 +1,191 @@
+// internal/core/alert_store.go
+package core
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// AlertQueryFilter restreint les résultats d'AlertStore.Query à un
+// sous-ensemble des alertes stockées. Un champ laissé à sa valeur zéro n'est
+// pas appliqué. Distinct du AlertFilter de config.go, qui décrit les règles
+// de routage d'un NotificationChannel.
+type AlertQueryFilter struct {
+	ID           string
+	CameraID     string
+	Type         AlertType
+	Level        AlertLevel
+	Since        time.Time
+	Until        time.Time
+	Acknowledged *bool
+}
+
+func (f AlertQueryFilter) matches(alert Alert) bool {
+	if f.ID != "" && alert.ID != f.ID {
+		return false
+	}
+	if f.CameraID != "" && alert.CameraID != f.CameraID {
+		return false
+	}
+	if f.Type != "" && alert.Type != f.Type {
+		return false
+	}
+	if f.Level != "" && alert.Level != f.Level {
+		return false
+	}
+	if !f.Since.IsZero() && alert.Timestamp.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && alert.Timestamp.After(f.Until) {
+		return false
+	}
+	if f.Acknowledged != nil && alert.Acknowledged != *f.Acknowledged {
+		return false
+	}
+	return true
+}
+
+// AlertStore persiste les alertes pour le compte d'un AlertManager. Trois
+// implémentations sont fournies : memoryStore (en mémoire, non persistant,
+// utilisé par défaut et dans les tests), sqliteStore et postgresStore (voir
+// alert_store_sql.go), sélectionnées via NewAlertStore selon DatabaseConfig.
+type AlertStore interface {
+	// Add insère une alerte. Les implémentations SQL effectuent un INSERT
+	// indexé par timestamp ; memoryStore maintient un ordre trié en mémoire.
+	Add(alert Alert) error
+	// Query retourne les alertes correspondant à filter, triées par
+	// timestamp décroissant, avec pagination limit/offset.
+	Query(filter AlertQueryFilter, limit, offset int) ([]Alert, error)
+	// Ack marque une alerte comme acquittée par userID.
+	Ack(alertID, userID string) error
+	// Stats agrège les compteurs globaux (total, acquittées, par niveau/type).
+	Stats() (AlertStats, error)
+	// Cleanup supprime les alertes plus anciennes que retention.
+	Cleanup(retention time.Duration) error
+}
+
+// memoryStore est l'implémentation AlertStore par défaut : un slice trié par
+// timestamp décroissant protégé par un mutex, sans persistance entre
+// redémarrages. C'est le comportement historique d'alertManager avant
+// l'introduction d'AlertStore.
+type memoryStore struct {
+	mutex  sync.RWMutex
+	alerts []Alert
+}
+
+// newMemoryStore crée un AlertStore en mémoire.
+func newMemoryStore() AlertStore {
+	return &memoryStore{alerts: make([]Alert, 0)}
+}
+
+func (s *memoryStore) Add(alert Alert) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// Recherche dichotomique du point d'insertion : s.alerts est maintenu
+	// trié par timestamp décroissant, donc on cherche le premier élément
+	// plus ancien que alert.
+	insertIndex := sort.Search(len(s.alerts), func(i int) bool {
+		return s.alerts[i].Timestamp.Before(alert.Timestamp)
+	})
+
+	s.alerts = append(s.alerts, Alert{})
+	copy(s.alerts[insertIndex+1:], s.alerts[insertIndex:])
+	s.alerts[insertIndex] = alert
+	return nil
+}
+
+func (s *memoryStore) Query(filter AlertQueryFilter, limit, offset int) ([]Alert, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	matched := make([]Alert, 0, len(s.alerts))
+	for _, alert := range s.alerts {
+		if filter.matches(alert) {
+			matched = append(matched, alert)
+		}
+	}
+
+	if offset >= len(matched) {
+		return []Alert{}, nil
+	}
+
+	end := len(matched)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+
+	result := make([]Alert, end-offset)
+	copy(result, matched[offset:end])
+	return result, nil
+}
+
+func (s *memoryStore) Ack(alertID, userID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for i := range s.alerts {
+		if s.alerts[i].ID == alertID {
+			now := time.Now()
+			s.alerts[i].Acknowledged = true
+			s.alerts[i].AckedBy = userID
+			s.alerts[i].AckedAt = &now
+			return nil
+		}
+	}
+	return ErrAlertNotFound
+}
+
+func (s *memoryStore) Stats() (AlertStats, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	stats := AlertStats{
+		Total:   len(s.alerts),
+		ByLevel: make(map[AlertLevel]int),
+		ByType:  make(map[AlertType]int),
+	}
+
+	for _, alert := range s.alerts {
+		if alert.Acknowledged {
+			stats.Acknowledged++
+		} else {
+			stats.Pending++
+		}
+		stats.ByLevel[alert.Level]++
+		stats.ByType[alert.Type]++
+	}
+
+	return stats, nil
+}
+
+func (s *memoryStore) Cleanup(retention time.Duration) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	cutoff := time.Now().Add(-retention)
+	filtered := make([]Alert, 0, len(s.alerts))
+	for _, alert := range s.alerts {
+		if alert.Timestamp.After(cutoff) {
+			filtered = append(filtered, alert)
+		}
+	}
+	s.alerts = filtered
+	return nil
+}
+
+// NewAlertStore construit l'AlertStore approprié selon config.Type
+// ("sqlite", "postgres", tout autre valeur y compris "memory" retombant sur
+// memoryStore). Les backends SQL ouvrent la connexion et exécutent leurs
+// migrations avant de retourner.
+func NewAlertStore(config DatabaseConfig) (AlertStore, error) {
+	switch config.Type {
+	case "sqlite":
+		return newSQLiteStore(config.URL, config.MaxConns)
+	case "postgres":
+		return newPostgresStore(config.URL, config.MaxConns)
+	default:
+		return newMemoryStore(), nil
+	}
+}
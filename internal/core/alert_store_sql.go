@@ -0,0 +1,265 @@
+// internal/core/alert_store_sql.go
+package core
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// sqlStore est l'implémentation AlertStore partagée par sqliteStore et
+// postgresStore : même schéma, mêmes requêtes, seules la syntaxe des
+// placeholders ($1 vs ?, voir placeholder()) et la requête de totaux de
+// Stats diffèrent selon le driver.
+type sqlStore struct {
+	db     *sql.DB
+	driver string // "sqlite3" ou "postgres", pour placeholder()
+}
+
+const alertStoreSchema = `
+CREATE TABLE IF NOT EXISTS alerts (
+	id            TEXT PRIMARY KEY,
+	camera_id     TEXT NOT NULL,
+	type          TEXT NOT NULL,
+	level         TEXT NOT NULL,
+	message       TEXT NOT NULL,
+	metadata      TEXT,
+	timestamp     TIMESTAMP NOT NULL,
+	acknowledged  BOOLEAN NOT NULL DEFAULT FALSE,
+	acked_by      TEXT,
+	acked_at      TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_alerts_timestamp ON alerts (timestamp DESC);
+CREATE INDEX IF NOT EXISTS idx_alerts_camera_id ON alerts (camera_id);
+`
+
+// newSQLiteStore ouvre (et crée si besoin) une base SQLite à url et exécute
+// les migrations. url est un chemin de fichier (ex: "surveillance.db").
+func newSQLiteStore(url string, maxConns int) (AlertStore, error) {
+	db, err := sql.Open("sqlite3", url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+	db.SetMaxOpenConns(1) // SQLite ne supporte pas l'écriture concurrente
+	return newSQLStore(db, "sqlite3")
+}
+
+// newPostgresStore ouvre un pool de connexions Postgres vers url et exécute
+// les migrations.
+func newPostgresStore(url string, maxConns int) (AlertStore, error) {
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open postgres database: %w", err)
+	}
+	if maxConns > 0 {
+		db.SetMaxOpenConns(maxConns)
+	}
+	return newSQLStore(db, "postgres")
+}
+
+// newSQLStore valide la connexion et applique le schéma avant de retourner
+// le store, qu'il soit sqlite ou postgres.
+func newSQLStore(db *sql.DB, driver string) (AlertStore, error) {
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to %s database: %w", driver, err)
+	}
+
+	store := &sqlStore{db: db, driver: driver}
+	if _, err := db.Exec(alertStoreSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to run %s migrations: %w", driver, err)
+	}
+	return store, nil
+}
+
+// placeholder retourne le marqueur de paramètre positionnel attendu par le
+// driver ("?" pour sqlite3, "$N" pour postgres).
+func (s *sqlStore) placeholder(n int) string {
+	if s.driver == "postgres" {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+func (s *sqlStore) Add(alert Alert) error {
+	metadata, err := json.Marshal(alert.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert metadata: %w", err)
+	}
+
+	query := fmt.Sprintf(
+		`INSERT INTO alerts (id, camera_id, type, level, message, metadata, timestamp, acknowledged, acked_by, acked_at)
+		 VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s)`,
+		s.placeholder(1), s.placeholder(2), s.placeholder(3), s.placeholder(4), s.placeholder(5),
+		s.placeholder(6), s.placeholder(7), s.placeholder(8), s.placeholder(9), s.placeholder(10),
+	)
+	_, err = s.db.Exec(query,
+		alert.ID, alert.CameraID, alert.Type, alert.Level, alert.Message,
+		string(metadata), alert.Timestamp, alert.Acknowledged, alert.AckedBy, alert.AckedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert alert: %w", err)
+	}
+	return nil
+}
+
+// Query construit dynamiquement la clause WHERE à partir de filter puis
+// délègue le tri et la pagination à la base (ORDER BY timestamp DESC LIMIT
+// OFFSET), au lieu de charger toutes les alertes en mémoire.
+func (s *sqlStore) Query(filter AlertQueryFilter, limit, offset int) ([]Alert, error) {
+	var conditions []string
+	var args []interface{}
+
+	addCondition := func(clause string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(clause, s.placeholder(len(args))))
+	}
+
+	if filter.ID != "" {
+		addCondition("id = %s", filter.ID)
+	}
+	if filter.CameraID != "" {
+		addCondition("camera_id = %s", filter.CameraID)
+	}
+	if filter.Type != "" {
+		addCondition("type = %s", filter.Type)
+	}
+	if filter.Level != "" {
+		addCondition("level = %s", filter.Level)
+	}
+	if !filter.Since.IsZero() {
+		addCondition("timestamp >= %s", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		addCondition("timestamp <= %s", filter.Until)
+	}
+	if filter.Acknowledged != nil {
+		addCondition("acknowledged = %s", *filter.Acknowledged)
+	}
+
+	query := "SELECT id, camera_id, type, level, message, metadata, timestamp, acknowledged, acked_by, acked_at FROM alerts"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY timestamp DESC"
+	if limit > 0 {
+		args = append(args, limit)
+		query += fmt.Sprintf(" LIMIT %s", s.placeholder(len(args)))
+		args = append(args, offset)
+		query += fmt.Sprintf(" OFFSET %s", s.placeholder(len(args)))
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query alerts: %w", err)
+	}
+	defer rows.Close()
+
+	alerts := make([]Alert, 0)
+	for rows.Next() {
+		var alert Alert
+		var metadata sql.NullString
+		var ackedBy sql.NullString
+		var ackedAt sql.NullTime
+		if err := rows.Scan(
+			&alert.ID, &alert.CameraID, &alert.Type, &alert.Level, &alert.Message,
+			&metadata, &alert.Timestamp, &alert.Acknowledged, &ackedBy, &ackedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan alert row: %w", err)
+		}
+		if metadata.Valid && metadata.String != "" {
+			if err := json.Unmarshal([]byte(metadata.String), &alert.Metadata); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal alert metadata: %w", err)
+			}
+		}
+		alert.AckedBy = ackedBy.String
+		if ackedAt.Valid {
+			alert.AckedAt = &ackedAt.Time
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts, rows.Err()
+}
+
+func (s *sqlStore) Ack(alertID, userID string) error {
+	query := fmt.Sprintf(
+		"UPDATE alerts SET acknowledged = %s, acked_by = %s, acked_at = %s WHERE id = %s",
+		s.placeholder(1), s.placeholder(2), s.placeholder(3), s.placeholder(4),
+	)
+	result, err := s.db.Exec(query, true, userID, time.Now(), alertID)
+	if err != nil {
+		return fmt.Errorf("failed to acknowledge alert: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to read acknowledge result: %w", err)
+	}
+	if affected == 0 {
+		return ErrAlertNotFound
+	}
+	return nil
+}
+
+func (s *sqlStore) Stats() (AlertStats, error) {
+	stats := AlertStats{
+		ByLevel: make(map[AlertLevel]int),
+		ByType:  make(map[AlertType]int),
+	}
+
+	totalsQuery := "SELECT COUNT(*), COUNT(*) FILTER (WHERE acknowledged) FROM alerts"
+	if s.driver == "sqlite3" {
+		totalsQuery = "SELECT COUNT(*), SUM(CASE WHEN acknowledged THEN 1 ELSE 0 END) FROM alerts"
+	}
+	row := s.db.QueryRow(totalsQuery)
+	var acknowledged sql.NullInt64
+	if err := row.Scan(&stats.Total, &acknowledged); err != nil {
+		return stats, fmt.Errorf("failed to query alert totals: %w", err)
+	}
+	stats.Acknowledged = int(acknowledged.Int64)
+	stats.Pending = stats.Total - stats.Acknowledged
+
+	levelRows, err := s.db.Query("SELECT level, COUNT(*) FROM alerts GROUP BY level")
+	if err != nil {
+		return stats, fmt.Errorf("failed to query alert counts by level: %w", err)
+	}
+	defer levelRows.Close()
+	for levelRows.Next() {
+		var level AlertLevel
+		var count int
+		if err := levelRows.Scan(&level, &count); err != nil {
+			return stats, fmt.Errorf("failed to scan alert level count: %w", err)
+		}
+		stats.ByLevel[level] = count
+	}
+
+	typeRows, err := s.db.Query("SELECT type, COUNT(*) FROM alerts GROUP BY type")
+	if err != nil {
+		return stats, fmt.Errorf("failed to query alert counts by type: %w", err)
+	}
+	defer typeRows.Close()
+	for typeRows.Next() {
+		var alertType AlertType
+		var count int
+		if err := typeRows.Scan(&alertType, &count); err != nil {
+			return stats, fmt.Errorf("failed to scan alert type count: %w", err)
+		}
+		stats.ByType[alertType] = count
+	}
+
+	return stats, nil
+}
+
+func (s *sqlStore) Cleanup(retention time.Duration) error {
+	cutoff := time.Now().Add(-retention)
+	query := fmt.Sprintf("DELETE FROM alerts WHERE timestamp < %s", s.placeholder(1))
+	if _, err := s.db.Exec(query, cutoff); err != nil {
+		return fmt.Errorf("failed to clean up old alerts: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+// internal/core/alert_store_sql_test.go
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSQLStore_Placeholder(t *testing.T) {
+	sqlite := &sqlStore{driver: "sqlite3"}
+	if got := sqlite.placeholder(3); got != "?" {
+		t.Errorf("sqlite3 placeholder: expected %q, got %q", "?", got)
+	}
+
+	postgres := &sqlStore{driver: "postgres"}
+	if got := postgres.placeholder(3); got != "$3" {
+		t.Errorf("postgres placeholder: expected %q, got %q", "$3", got)
+	}
+}
+
+func newTestSQLiteStore(tb testing.TB) AlertStore {
+	tb.Helper()
+	store, err := NewAlertStore(DatabaseConfig{Type: "sqlite", URL: ":memory:"})
+	if err != nil {
+		tb.Fatalf("NewAlertStore: %v", err)
+	}
+	return store
+}
+
+func TestSQLiteStore_AddQueryAck(t *testing.T) {
+	store := newTestSQLiteStore(t)
+
+	alert := createTestAlert("cam_sql", AlertLevelWarning, 0)
+	if err := store.Add(alert); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	alerts, err := store.Query(AlertQueryFilter{CameraID: "cam_sql"}, 10, 0)
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(alerts) != 1 || alerts[0].ID != alert.ID {
+		t.Fatalf("expected to find the inserted alert, got %+v", alerts)
+	}
+
+	if err := store.Ack(alert.ID, "operator1"); err != nil {
+		t.Fatalf("Ack: %v", err)
+	}
+	acked, err := store.Query(AlertQueryFilter{ID: alert.ID}, 1, 0)
+	if err != nil {
+		t.Fatalf("Query after ack: %v", err)
+	}
+	if len(acked) != 1 || !acked[0].Acknowledged || acked[0].AckedBy != "operator1" {
+		t.Fatalf("expected alert to be acknowledged by operator1, got %+v", acked)
+	}
+
+	if err := store.Ack("does-not-exist", "operator1"); err != ErrAlertNotFound {
+		t.Errorf("expected ErrAlertNotFound for an unknown alert, got %v", err)
+	}
+}
+
+func TestSQLiteStore_QueryPagination(t *testing.T) {
+	store := newTestSQLiteStore(t)
+
+	for i := 0; i < 5; i++ {
+		if err := store.Add(createTestAlert("cam_page", AlertLevelInfo, i)); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	page1, err := store.Query(AlertQueryFilter{}, 2, 0)
+	if err != nil {
+		t.Fatalf("Query page1: %v", err)
+	}
+	page2, err := store.Query(AlertQueryFilter{}, 2, 2)
+	if err != nil {
+		t.Fatalf("Query page2: %v", err)
+	}
+	if len(page1) != 2 || len(page2) != 2 {
+		t.Fatalf("expected 2 alerts per page, got %d and %d", len(page1), len(page2))
+	}
+	if page1[0].ID == page2[0].ID {
+		t.Error("expected distinct pages to return distinct alerts")
+	}
+}
+
+func TestSQLiteStore_Stats(t *testing.T) {
+	store := newTestSQLiteStore(t)
+
+	store.Add(createTestAlert("cam1", AlertLevelWarning, 0))
+	store.Add(createTestAlert("cam1", AlertLevelCritical, 0))
+	second := createTestAlert("cam2", AlertLevelWarning, 0)
+	store.Add(second)
+	if err := store.Ack(second.ID, "op"); err != nil {
+		t.Fatalf("Ack: %v", err)
+	}
+
+	stats, err := store.Stats()
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	if stats.Total != 3 {
+		t.Errorf("expected Total=3, got %d", stats.Total)
+	}
+	if stats.Acknowledged != 1 {
+		t.Errorf("expected Acknowledged=1, got %d", stats.Acknowledged)
+	}
+	if stats.Pending != 2 {
+		t.Errorf("expected Pending=2, got %d", stats.Pending)
+	}
+	if stats.ByLevel[AlertLevelWarning] != 2 {
+		t.Errorf("expected 2 warning alerts, got %d", stats.ByLevel[AlertLevelWarning])
+	}
+	if stats.ByType[AlertTypeMotion] != 3 {
+		t.Errorf("expected 3 motion alerts, got %d", stats.ByType[AlertTypeMotion])
+	}
+}
+
+func TestSQLiteStore_Cleanup(t *testing.T) {
+	store := newTestSQLiteStore(t)
+
+	store.Add(createTestAlert("cam1", AlertLevelInfo, 120))
+	recent := createTestAlert("cam1", AlertLevelInfo, 1)
+	store.Add(recent)
+
+	if err := store.Cleanup(time.Hour); err != nil {
+		t.Fatalf("Cleanup: %v", err)
+	}
+
+	remaining, err := store.Query(AlertQueryFilter{}, 10, 0)
+	if err != nil {
+		t.Fatalf("Query after cleanup: %v", err)
+	}
+	if len(remaining) != 1 || remaining[0].ID != recent.ID {
+		t.Fatalf("expected only the recent alert to survive cleanup, got %+v", remaining)
+	}
+}
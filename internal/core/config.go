@@ -2,116 +2,354 @@
 package core
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"log"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"gopkg.in/yaml.v3"
 )
 
 // Config représente la configuration globale améliorée
 type Config struct {
 	// Serveur
-	Server ServerConfig `json:"server"`
+	Server ServerConfig `json:"server" yaml:"server"`
 
 	// Services externes
-	VisionService VisionServiceConfig `json:"vision_service"`
+	VisionService VisionServiceConfig `json:"vision_service" yaml:"vision_service"`
 
 	// Base de données
-	Database DatabaseConfig `json:"database"`
+	Database DatabaseConfig `json:"database" yaml:"database"`
 
 	// Caméras
-	Cameras CameraManagerConfig `json:"cameras"`
+	Cameras CameraManagerConfig `json:"cameras" yaml:"cameras"`
 
 	// Alertes
-	Alerts AlertConfig `json:"alerts"`
+	Alerts AlertConfig `json:"alerts" yaml:"alerts"`
 
 	// Logs
-	Logging LoggingConfig `json:"logging"`
+	Logging LoggingConfig `json:"logging" yaml:"logging"`
 
 	// Sécurité
-	Security SecurityConfig `json:"security"`
+	Security SecurityConfig `json:"security" yaml:"security"`
+
+	// Flux d'événements (NDJSON/gRPC) pour consommateurs externes
+	EventStream EventStreamConfig `json:"event_stream" yaml:"event_stream"`
+
+	// Taille de file et politique de saturation par shard d'EventProcessor
+	EventProcessing EventProcessingConfig `json:"event_processing" yaml:"event_processing"`
+
+	// Hub WebSocket (diffusion temps réel)
+	WebSocket WebSocketConfig `json:"websocket" yaml:"websocket"`
+
+	// Renseignement (listes de décisions distantes, façon CrowdSec LAPI)
+	Intelligence IntelligenceConfig `json:"intelligence" yaml:"intelligence"`
+
+	// Observabilité (export de traces OpenTelemetry)
+	Observability ObservabilityConfig `json:"observability" yaml:"observability"`
 }
 
 type ServerConfig struct {
-	Port            string        `json:"port"`
-	Host            string        `json:"host"`
-	ReadTimeout     time.Duration `json:"read_timeout"`
-	WriteTimeout    time.Duration `json:"write_timeout"`
-	ShutdownTimeout time.Duration `json:"shutdown_timeout"`
-	TLSEnabled      bool          `json:"tls_enabled"`
-	CertFile        string        `json:"cert_file"`
-	KeyFile         string        `json:"key_file"`
+	Port            string        `json:"port" yaml:"port"`
+	Host            string        `json:"host" yaml:"host"`
+	ReadTimeout     time.Duration `json:"read_timeout" yaml:"read_timeout"`
+	WriteTimeout    time.Duration `json:"write_timeout" yaml:"write_timeout"`
+	ShutdownTimeout time.Duration `json:"shutdown_timeout" yaml:"shutdown_timeout"`
+	TLSEnabled      bool          `json:"tls_enabled" yaml:"tls_enabled"`
+	CertFile        string        `json:"cert_file" yaml:"cert_file"`
+	KeyFile         string        `json:"key_file" yaml:"key_file"`
 }
 
 type VisionServiceConfig struct {
-	Address             string        `json:"address"`
-	Timeout             time.Duration `json:"timeout"`
-	MaxRetries          int           `json:"max_retries"`
-	RetryInterval       time.Duration `json:"retry_interval"`
-	HealthCheckInterval time.Duration `json:"health_check_interval"`
+	Address             string        `json:"address" yaml:"address"`
+	Timeout             time.Duration `json:"timeout" yaml:"timeout"`
+	MaxRetries          int           `json:"max_retries" yaml:"max_retries"`
+	RetryInterval       time.Duration `json:"retry_interval" yaml:"retry_interval"`
+	HealthCheckInterval time.Duration `json:"health_check_interval" yaml:"health_check_interval"`
+	// TLSCfg configure le transport gRPC (TLS/mTLS) vers le service vision,
+	// sur le même modèle que NotificationChannel.TLS pour les canaux HTTP.
+	TLSCfg TLSCfg `json:"tls,omitempty" yaml:"tls,omitempty"`
 }
 
 type CameraManagerConfig struct {
-	MaxCameras           int           `json:"max_cameras"`
-	DefaultFPS           int           `json:"default_fps"`
-	DefaultQuality       int           `json:"default_quality"`
-	StreamTimeout        time.Duration `json:"stream_timeout"`
-	ReconnectDelay       time.Duration `json:"reconnect_delay"`
-	MaxReconnectAttempts int           `json:"max_reconnect_attempts"`
+	MaxCameras           int           `json:"max_cameras" yaml:"max_cameras"`
+	DefaultFPS           int           `json:"default_fps" yaml:"default_fps"`
+	DefaultQuality       int           `json:"default_quality" yaml:"default_quality"`
+	StreamTimeout        time.Duration `json:"stream_timeout" yaml:"stream_timeout"`
+	ReconnectDelay       time.Duration `json:"reconnect_delay" yaml:"reconnect_delay"`
+	MaxReconnectAttempts int           `json:"max_reconnect_attempts" yaml:"max_reconnect_attempts"`
 }
 
 type AlertConfig struct {
-	Retention            time.Duration         `json:"retention"`
-	MaxAlerts            int                   `json:"max_alerts"`
-	NotificationChannels []NotificationChannel `json:"notification_channels"`
-	ThrottleInterval     time.Duration         `json:"throttle_interval"`
-	BatchSize            int                   `json:"batch_size"`
+	Retention            time.Duration         `json:"retention" yaml:"retention"`
+	MaxAlerts            int                   `json:"max_alerts" yaml:"max_alerts"`
+	NotificationChannels []NotificationChannel `json:"notification_channels" yaml:"notification_channels"`
+	ThrottleInterval     time.Duration         `json:"throttle_interval" yaml:"throttle_interval"`
+	BatchSize            int                   `json:"batch_size" yaml:"batch_size"`
+	// ReportOnly bascule le NotifierRegistry en mode digest : seuls les
+	// SessionReport de fin de cycle sont envoyés, pas les alertes une à une.
+	ReportOnly bool `json:"report_only" yaml:"report_only"`
+	// AlertTemplatePath et ReportTemplatePath pointent vers des templates
+	// text/template sur disque pour personnaliser respectivement le message
+	// d'alerte individuelle et le digest de session. Vides, chaque notifier
+	// utilise son gabarit par défaut intégré.
+	AlertTemplatePath  string `json:"alert_template_path" yaml:"alert_template_path"`
+	ReportTemplatePath string `json:"report_template_path" yaml:"report_template_path"`
 }
 
 type NotificationChannel struct {
-	Name    string            `json:"name"`
-	Type    string            `json:"type"` // "email", "webhook", "sms"
-	Enabled bool              `json:"enabled"`
-	Config  map[string]string `json:"config"`
-	Filters []AlertFilter     `json:"filters"`
+	Name    string            `json:"name" yaml:"name"`
+	Type    string            `json:"type" yaml:"type"` // "email", "webhook", "sms"
+	Enabled bool              `json:"enabled" yaml:"enabled"`
+	Config  map[string]string `json:"config" yaml:"config"`
+	Filters []AlertFilter     `json:"filters" yaml:"filters"`
+	// TLS configure le transport (HTTP ou SMTP) de ce canal, pour pousser
+	// les alertes vers un service interne derrière TLS/mTLS.
+	TLS TLSCfg `json:"tls,omitempty" yaml:"tls,omitempty"`
+	// Retry borne les tentatives de renvoi avant d'abandonner et de compter
+	// l'échec pour Breaker. Zéro = DefaultRetryConfig().
+	Retry RetryConfig `json:"retry,omitempty" yaml:"retry,omitempty"`
+	// Breaker pilote le circuit breaker de ce canal. Zéro = DefaultBreakerConfig().
+	Breaker BreakerConfig `json:"breaker,omitempty" yaml:"breaker,omitempty"`
 }
 
+// AlertFilter restreint les alertes routées vers un NotificationChannel. Un
+// champ laissé à sa valeur zéro n'est pas appliqué, et un NotificationChannel
+// sans aucun AlertFilter reçoit toutes les alertes (pas de filtrage).
+// Distinct de l'AlertQueryFilter d'alert_store.go, qui filtre les résultats
+// d'une requête de consultation plutôt que le routage des notifications.
 type AlertFilter struct {
-	Level    AlertLevel `json:"level,omitempty"`
-	Type     AlertType  `json:"type,omitempty"`
-	CameraID string     `json:"camera_id,omitempty"`
+	Level    AlertLevel `json:"level,omitempty" yaml:"level,omitempty"`
+	Type     AlertType  `json:"type,omitempty" yaml:"type,omitempty"`
+	CameraID string     `json:"camera_id,omitempty" yaml:"camera_id,omitempty"`
+}
+
+// Matches retourne true si alert satisfait ce filtre (chaque champ non-zéro
+// doit correspondre exactement).
+func (f AlertFilter) Matches(alert Alert) bool {
+	if f.Level != "" && alert.Level != f.Level {
+		return false
+	}
+	if f.Type != "" && alert.Type != f.Type {
+		return false
+	}
+	if f.CameraID != "" && alert.CameraID != f.CameraID {
+		return false
+	}
+	return true
+}
+
+// TLSCfg configure un transport TLS/mTLS, réutilisable par tout canal de
+// notification HTTP ou SMTP qui a besoin de parler à un service interne
+// chiffré plutôt qu'en clair, sur le même modèle qu'utilise déjà
+// ServerConfig.CertFile/KeyFile pour le serveur HTTP lui-même.
+type TLSCfg struct {
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// CertFile/KeyFile, si renseignés, authentifient ce client auprès du
+	// serveur distant (mTLS). Vides = pas de certificat client présenté.
+	CertFile string `json:"cert_file,omitempty" yaml:"cert_file,omitempty"`
+	KeyFile  string `json:"key_file,omitempty" yaml:"key_file,omitempty"`
+	// CAFile, si renseigné, remplace le pool de CA système pour valider le
+	// certificat du serveur distant (utile pour une CA interne).
+	CAFile string `json:"ca_file,omitempty" yaml:"ca_file,omitempty"`
+	// ServerName force le nom attendu dans le certificat serveur, pour les
+	// déploiements qui s'y connectent par IP plutôt que par nom DNS.
+	ServerName string `json:"server_name,omitempty" yaml:"server_name,omitempty"`
+	// InsecureSkipVerify désactive la vérification du certificat serveur.
+	// Réservé aux environnements de test : jamais en production.
+	InsecureSkipVerify bool `json:"insecure_skip_verify,omitempty" yaml:"insecure_skip_verify,omitempty"`
+}
+
+// RetryConfig borne les tentatives de renvoi d'un Notifier.Send/SendReport
+// avant d'abandonner, avec un backoff exponentiel entre chaque tentative.
+type RetryConfig struct {
+	MaxAttempts    int           `json:"max_attempts" yaml:"max_attempts"`
+	InitialBackoff time.Duration `json:"initial_backoff" yaml:"initial_backoff"`
+	MaxBackoff     time.Duration `json:"max_backoff" yaml:"max_backoff"`
+}
+
+// DefaultRetryConfig retourne les réglages appliqués quand un
+// NotificationChannel ne précise pas Retry.
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts:    3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+	}
+}
+
+// BreakerConfig pilote le circuit breaker associé à un canal de
+// notification : il s'ouvre après FailureThreshold échecs consécutifs
+// (Send/SendReport court-circuité en erreur immédiate) et repasse en
+// half-open après OpenDuration pour retenter un envoi.
+type BreakerConfig struct {
+	FailureThreshold int           `json:"failure_threshold" yaml:"failure_threshold"`
+	OpenDuration     time.Duration `json:"open_duration" yaml:"open_duration"`
+}
+
+// DefaultBreakerConfig retourne les réglages appliqués quand un
+// NotificationChannel ne précise pas Breaker.
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{
+		FailureThreshold: 5,
+		OpenDuration:     30 * time.Second,
+	}
 }
 
 type LoggingConfig struct {
-	Level      string `json:"level"`
-	Format     string `json:"format"` // "json", "text"
-	Output     string `json:"output"` // "stdout", "file", "both"
-	File       string `json:"file"`
-	MaxSize    int    `json:"max_size"` // MB
-	MaxAge     int    `json:"max_age"`  // Days
-	MaxBackups int    `json:"max_backups"`
-	Compress   bool   `json:"compress"`
+	Level      string `json:"level" yaml:"level"`
+	Format     string `json:"format" yaml:"format"` // "json", "text"
+	Output     string `json:"output" yaml:"output"` // "stdout", "file", "both"
+	File       string `json:"file" yaml:"file"`
+	MaxSize    int    `json:"max_size" yaml:"max_size"` // MB
+	MaxAge     int    `json:"max_age" yaml:"max_age"`   // Days
+	MaxBackups int    `json:"max_backups" yaml:"max_backups"`
+	Compress   bool   `json:"compress" yaml:"compress"`
+}
+
+type EventStreamConfig struct {
+	GRPCAddress       string        `json:"grpc_address" yaml:"grpc_address"`
+	ReplayBufferSize  int           `json:"replay_buffer_size" yaml:"replay_buffer_size"`
+	SubscriberBuffer  int           `json:"subscriber_buffer" yaml:"subscriber_buffer"`
+	HeartbeatInterval time.Duration `json:"heartbeat_interval" yaml:"heartbeat_interval"`
+}
+
+// EventProcessingConfig pilote EventProcessorConfig (voir
+// core.NewEventProcessorWithConfig) : la taille de la file de détection par
+// shard et la politique appliquée quand l'une d'elles est pleine, pour les
+// déploiements qui veulent ajuster la backpressure au débit réel de leurs
+// caméras plutôt que d'utiliser DefaultEventProcessorConfig.
+type EventProcessingConfig struct {
+	QueueSize  int        `json:"queue_size" yaml:"queue_size"`
+	DropPolicy DropPolicy `json:"drop_policy" yaml:"drop_policy"`
+}
+
+// WebSocketConfig configure le Hub de diffusion temps réel (internal/websocket).
+type WebSocketConfig struct {
+	// ReplayBufferSize est le nombre de messages retenus par topic pour que
+	// les clients qui se connectent ou se réabonnent tardivement puissent
+	// rattraper les alertes/métadonnées de frame récentes (voir Hub.BroadcastTo).
+	ReplayBufferSize int `json:"replay_buffer_size" yaml:"replay_buffer_size"`
+}
+
+// IntelligenceConfig pilote IntelDetector (voir intel.go) : les listes de
+// décisions tirées périodiquement d'un ou plusieurs endpoints distants
+// (façon flux de décisions CrowdSec LAPI : IP, hash de visage, plaque
+// d'immatriculation à refuser), plus les overrides locaux gérés par le
+// sous-commande cscli (cmd/cscli).
+type IntelligenceConfig struct {
+	// Enabled bascule l'enregistrement d'IntelDetector auprès de
+	// l'EventProcessor au démarrage (voir cmd/server/main.go).
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// Sources liste les endpoints à interroger ; tous alimentent le même
+	// cache, chaque décision y ayant son propre TTL.
+	Sources []IntelSource `json:"sources" yaml:"sources"`
+	// OverridesPath pointe vers le fichier JSON des décisions locales
+	// ajoutées/retirées via cscli, fusionnées aux décisions distantes sans
+	// attendre le prochain pull.
+	OverridesPath string `json:"overrides_path" yaml:"overrides_path"`
+}
+
+// ObservabilityConfig pilote l'export des traces OpenTelemetry du pipeline
+// d'événements (ingestion de frame, évaluation des détecteurs, dispatch
+// d'alerte). OTLPEndpoint vide désactive l'export : telemetry.Init installe
+// alors un TracerProvider no-op plutôt que d'échouer au démarrage.
+type ObservabilityConfig struct {
+	// OTLPEndpoint est l'adresse gRPC du collecteur OTLP (ex:
+	// "otel-collector:4317"). Vide désactive le tracing.
+	OTLPEndpoint string `json:"otlp_endpoint" yaml:"otlp_endpoint"`
+	// SamplingRatio est la fraction des traces effectivement échantillonnées
+	// (0 = aucune, 1 = toutes).
+	SamplingRatio float64 `json:"sampling_ratio" yaml:"sampling_ratio"`
+	// ServiceName identifie ce processus dans le backend de tracing.
+	ServiceName string `json:"service_name" yaml:"service_name"`
+}
+
+// IntelSource déclare un endpoint de décisions distant au format CrowdSec
+// LAPI (tableau JSON de {type, value, duration}).
+type IntelSource struct {
+	Name string `json:"name" yaml:"name"`
+	URL  string `json:"url" yaml:"url"`
+	// BearerToken, si non vide, est envoyé en en-tête Authorization: Bearer.
+	BearerToken string `json:"bearer_token" yaml:"bearer_token"`
+	// RefreshInterval cadence les pulls de cette source. Zéro = pas de
+	// rafraîchissement automatique (pull initial seulement).
+	RefreshInterval time.Duration `json:"refresh_interval" yaml:"refresh_interval"`
+	// CacheTTL borne la durée de vie d'une décision en cache au-delà de sa
+	// propre Duration annoncée par la source (voir IntelDetector.mergeRemote).
+	CacheTTL time.Duration `json:"cache_ttl" yaml:"cache_ttl"`
 }
 
 type SecurityConfig struct {
-	JWTSecret        string        `json:"jwt_secret"`
-	JWTExpiry        time.Duration `json:"jwt_expiry"`
-	RateLimitEnabled bool          `json:"rate_limit_enabled"`
-	RateLimitRPS     int           `json:"rate_limit_rps"`
-	CORSEnabled      bool          `json:"cors_enabled"`
-	CORSOrigins      []string      `json:"cors_origins"`
+	JWTSecret        string        `json:"jwt_secret" yaml:"jwt_secret"`
+	JWTExpiry        time.Duration `json:"jwt_expiry" yaml:"jwt_expiry"`
+	RateLimitEnabled bool          `json:"rate_limit_enabled" yaml:"rate_limit_enabled"`
+	RateLimitRPS     int           `json:"rate_limit_rps" yaml:"rate_limit_rps"`
+	CORSEnabled      bool          `json:"cors_enabled" yaml:"cors_enabled"`
+	CORSOrigins      []string      `json:"cors_origins" yaml:"cors_origins"`
+}
+
+// ConfigOption personnalise le chargement effectué par LoadConfig. Les
+// options s'appliquent dans l'ordre de précédence croissante : fichier <
+// environnement < flags (la dernière source appliquée gagne).
+type ConfigOption func(*configLoadOptions)
+
+type configLoadOptions struct {
+	filePath string
+	flagSet  *flag.FlagSet
+	flagArgs []string
 }
 
-// LoadConfig charge la configuration depuis les variables d'environnement avec des valeurs par défaut
-func LoadConfig() (*Config, error) {
+// WithConfigFile charge path (JSON ou YAML, détecté par son contenu plutôt
+// que son extension) avant les variables d'environnement, comme base versionnée
+// sur laquelle l'environnement du déploiement vient ensuite surcharger des
+// valeurs ponctuelles. path vide désactive le chargement de fichier.
+func WithConfigFile(path string) ConfigOption {
+	return func(o *configLoadOptions) { o.filePath = path }
+}
+
+// WithFlags applique des overrides de ligne de commande après les variables
+// d'environnement (précédence la plus haute), via un *flag.FlagSet que
+// l'appelant a préparé : voir loadFromFlags pour les noms reconnus.
+func WithFlags(fs *flag.FlagSet, args []string) ConfigOption {
+	return func(o *configLoadOptions) { o.flagSet = fs; o.flagArgs = args }
+}
+
+// LoadConfig charge la configuration par couches successives : valeurs par
+// défaut, puis WithConfigFile (si fourni), puis variables d'environnement,
+// puis WithFlags (si fourni) — chaque couche ne surcharge que les champs
+// qu'elle renseigne explicitement.
+func LoadConfig(opts ...ConfigOption) (*Config, error) {
+	var options configLoadOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	config := getDefaultConfig()
 
-	// Charger depuis les variables d'environnement
+	if options.filePath != "" {
+		if err := loadFromFile(config, options.filePath); err != nil {
+			return nil, fmt.Errorf("failed to load config from file: %w", err)
+		}
+	}
+
 	if err := loadFromEnv(config); err != nil {
 		return nil, fmt.Errorf("failed to load config from environment: %w", err)
 	}
 
+	if options.flagSet != nil {
+		if err := loadFromFlags(config, options.flagSet, options.flagArgs); err != nil {
+			return nil, fmt.Errorf("failed to load config from flags: %w", err)
+		}
+	}
+
 	// Valider la configuration
 	if err := validateConfig(config); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
@@ -120,6 +358,61 @@ func LoadConfig() (*Config, error) {
 	return config, nil
 }
 
+// LoadConfigFromFile charge une configuration depuis path (JSON ou YAML) en
+// partant des valeurs par défaut pour les champs absents du fichier, puis
+// valide le résultat. Pour composer un fichier avec l'environnement, préférer
+// LoadConfig(WithConfigFile(path)).
+func LoadConfigFromFile(path string) (*Config, error) {
+	config := getDefaultConfig()
+	if err := loadFromFile(config, path); err != nil {
+		return nil, err
+	}
+	if err := validateConfig(config); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return config, nil
+}
+
+// loadFromFile décode path dans config. YAML étant un sur-ensemble de JSON,
+// un seul décodeur (yaml.v3) gère les deux formats, comme le fait déjà
+// RuleEngine.LoadRules pour les fichiers de règles.
+func loadFromFile(config *Config, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("ouverture du fichier de config %s: %w", path, err)
+	}
+	defer f.Close()
+
+	if err := yaml.NewDecoder(f).Decode(config); err != nil {
+		return fmt.Errorf("décodage du fichier de config %s: %w", path, err)
+	}
+	return nil
+}
+
+// loadFromFlags définit un petit sous-ensemble d'overrides de ligne de
+// commande sur fs puis les applique à config ; fs.Parse est appelé ici avec
+// args, l'appelant ne doit donc pas l'avoir fait lui-même.
+func loadFromFlags(config *Config, fs *flag.FlagSet, args []string) error {
+	port := fs.String("port", "", "override du port d'écoute (ex: :8080)")
+	logLevel := fs.String("log-level", "", "override du niveau de log (debug, info, warn, error)")
+
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("analyse des flags: %w", err)
+	}
+
+	if *port != "" {
+		if !strings.HasPrefix(*port, ":") {
+			*port = ":" + *port
+		}
+		config.Server.Port = *port
+	}
+	if *logLevel != "" {
+		config.Logging.Level = *logLevel
+	}
+
+	return nil
+}
+
 // getDefaultConfig retourne une configuration par défaut
 func getDefaultConfig() *Config {
 	return &Config{
@@ -152,11 +445,20 @@ func getDefaultConfig() *Config {
 			MaxReconnectAttempts: 5,
 		},
 		Alerts: AlertConfig{
-			Retention:        24 * time.Hour,
-			MaxAlerts:        1000,
-			ThrottleInterval: 30 * time.Second,
-			BatchSize:        10,
+			Retention:          24 * time.Hour,
+			MaxAlerts:          1000,
+			ThrottleInterval:   30 * time.Second,
+			BatchSize:          10,
+			ReportOnly:         false,
+			AlertTemplatePath:  "",
+			ReportTemplatePath: "",
 			NotificationChannels: []NotificationChannel{
+				{
+					Name:    "websocket",
+					Type:    "websocket",
+					Enabled: true,
+					Config:  map[string]string{},
+				},
 				{
 					Name:    "default",
 					Type:    "webhook",
@@ -174,6 +476,29 @@ func getDefaultConfig() *Config {
 			MaxBackups: 3,
 			Compress:   true,
 		},
+		EventStream: EventStreamConfig{
+			GRPCAddress:       ":50052",
+			ReplayBufferSize:  1000,
+			SubscriberBuffer:  64,
+			HeartbeatInterval: 15 * time.Second,
+		},
+		EventProcessing: EventProcessingConfig{
+			QueueSize:  DefaultEventProcessorConfig().QueueSize,
+			DropPolicy: DefaultEventProcessorConfig().DropPolicy,
+		},
+		WebSocket: WebSocketConfig{
+			ReplayBufferSize: 200,
+		},
+		Intelligence: IntelligenceConfig{
+			Enabled:       false,
+			Sources:       []IntelSource{},
+			OverridesPath: "intel_overrides.json",
+		},
+		Observability: ObservabilityConfig{
+			OTLPEndpoint:  "",
+			SamplingRatio: 0.1,
+			ServiceName:   "surveillance-core",
+		},
 		Security: SecurityConfig{
 			JWTSecret:        "change-me-in-production",
 			JWTExpiry:        24 * time.Hour,
@@ -285,6 +610,18 @@ func loadFromEnv(config *Config) error {
 		}
 	}
 
+	// Observabilité : noms d'env standard du SDK OpenTelemetry plutôt
+	// qu'une convention maison, pour rester compatible avec le tooling OTel
+	// existant (docker-compose, Helm charts, ...).
+	if endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); endpoint != "" {
+		config.Observability.OTLPEndpoint = endpoint
+	}
+	if ratioStr := os.Getenv("OTEL_TRACES_SAMPLER_ARG"); ratioStr != "" {
+		if ratio, err := strconv.ParseFloat(ratioStr, 64); err == nil {
+			config.Observability.SamplingRatio = ratio
+		}
+	}
+
 	return nil
 }
 
@@ -356,11 +693,160 @@ func (c *Config) GetServerAddress() string {
 	return c.Server.Host + c.Server.Port
 }
 
-// String retourne une représentation string de la config (sans secrets)
-func (c *Config) String() string {
+// Redacted retourne une copie de c avec les secrets remplacés par
+// "[REDACTED]", sûre à journaliser ou à exposer via l'API (voir
+// api.Handler.GetConfig) sans fuiter de identifiants.
+func (c *Config) Redacted() *Config {
 	safeConfig := *c
 	safeConfig.Security.JWTSecret = "[REDACTED]"
 
+	safeConfig.Alerts.NotificationChannels = make([]NotificationChannel, len(c.Alerts.NotificationChannels))
+	copy(safeConfig.Alerts.NotificationChannels, c.Alerts.NotificationChannels)
+	for i, channel := range safeConfig.Alerts.NotificationChannels {
+		redactedConfig := make(map[string]string, len(channel.Config))
+		for k, v := range channel.Config {
+			if isSecretChannelField(k) {
+				v = "[REDACTED]"
+			}
+			redactedConfig[k] = v
+		}
+		channel.Config = redactedConfig
+		safeConfig.Alerts.NotificationChannels[i] = channel
+	}
+
+	return &safeConfig
+}
+
+// isSecretChannelField identifie les clés de NotificationChannel.Config qui
+// portent un identifiant sensible (mot de passe, jeton, clé d'API), pour les
+// masquer dans Redacted().
+func isSecretChannelField(key string) bool {
+	switch strings.ToLower(key) {
+	case "password", "auth_token", "api_key", "token", "secret":
+		return true
+	default:
+		return false
+	}
+}
+
+// String retourne une représentation string de la config (sans secrets)
+func (c *Config) String() string {
+	safeConfig := c.Redacted()
+
 	return fmt.Sprintf("Config{Server: %+v, Cameras: %+v, Alerts: %+v}",
 		safeConfig.Server, safeConfig.Cameras, safeConfig.Alerts)
 }
+
+// ConfigManager détient la configuration vivante du processus et permet de
+// la recharger à chaud depuis un fichier sans redémarrer le serveur. Seules
+// les sections jugées sûres à rebasculer en cours de route sont appliquées
+// par Reload (niveau de log, rétention des alertes, canaux de notification,
+// FPS par défaut des caméras) ; le reste (port, TLS, adresses de services...)
+// nécessite toujours un redémarrage complet.
+type ConfigManager struct {
+	path    string
+	current atomic.Pointer[Config]
+}
+
+// NewConfigManager crée un gestionnaire initialisé avec initial. path est le
+// fichier JSON/YAML rechargé par Reload/Watch ; vide, le gestionnaire sert
+// uniquement de point d'accès en lecture à initial (Reload/Watch échouent).
+func NewConfigManager(initial *Config, path string) *ConfigManager {
+	cm := &ConfigManager{path: path}
+	cm.current.Store(initial)
+	return cm
+}
+
+// Get retourne la configuration actuellement effective.
+func (cm *ConfigManager) Get() *Config {
+	return cm.current.Load()
+}
+
+// Reload relit cm.path et, si le fichier est valide, applique ses sections
+// sûres à la configuration courante. Une erreur de lecture, de décodage ou
+// de validation laisse la configuration en place strictement inchangée
+// (rollback implicite : on ne stocke jamais un candidat invalide).
+func (cm *ConfigManager) Reload() error {
+	if cm.path == "" {
+		return fmt.Errorf("aucun fichier de config associé à ce gestionnaire")
+	}
+
+	candidate := getDefaultConfig()
+	if err := loadFromFile(candidate, cm.path); err != nil {
+		return err
+	}
+	if err := loadFromEnv(candidate); err != nil {
+		return err
+	}
+	if err := validateConfig(candidate); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	previous := cm.current.Load()
+	next := *previous
+	next.Logging.Level = candidate.Logging.Level
+	next.Alerts.Retention = candidate.Alerts.Retention
+	next.Alerts.NotificationChannels = candidate.Alerts.NotificationChannels
+	next.Cameras.DefaultFPS = candidate.Cameras.DefaultFPS
+
+	cm.current.Store(&next)
+	return nil
+}
+
+// Watch surveille le répertoire de cm.path via fsnotify et appelle Reload à
+// chaque modification du fichier ; si Reload réussit, onChange(cm.Get()) est
+// invoqué. Les échecs de Reload sont journalisés mais jamais fatals : la
+// configuration en place continue de servir. Watch bloque jusqu'à
+// l'annulation de ctx.
+func (cm *ConfigManager) Watch(ctx context.Context, onChange func(*Config)) error {
+	if cm.path == "" {
+		return fmt.Errorf("aucun fichier de config associé à ce gestionnaire")
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("création du watcher de config: %w", err)
+	}
+	defer watcher.Close()
+
+	// On surveille le répertoire plutôt que le fichier directement : de
+	// nombreux outils (et `kubectl edit` sur un ConfigMap monté) remplacent
+	// le fichier via rename atomique, ce qui invaliderait un watch posé sur
+	// l'inode d'origine.
+	if err := watcher.Add(filepath.Dir(cm.path)); err != nil {
+		return fmt.Errorf("surveillance de %s: %w", cm.path, err)
+	}
+
+	target := filepath.Clean(cm.path)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(event.Name) != target {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			if err := cm.Reload(); err != nil {
+				log.Printf("⚠️ Rechargement de %s ignoré, configuration précédente conservée: %v", cm.path, err)
+				continue
+			}
+			if onChange != nil {
+				onChange(cm.Get())
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.Printf("⚠️ Erreur de surveillance de %s: %v", cm.path, err)
+		}
+	}
+}
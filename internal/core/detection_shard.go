@@ -0,0 +1,149 @@
+// internal/core/detection_shard.go
+package core
+
+import (
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// detectionShardCount fixe le nombre de shards dans lesquels eventProcessor
+// répartit détecteurs et métriques, pour qu'un ProcessDetection sur une
+// caméra ne se dispute pas le même verrou qu'un ProcessDetection sur une
+// autre caméra sous forte charge (dizaines de caméras à 10-30 fps).
+const detectionShardCount = 16
+
+// detectionTimestampBufSize borne le buffer circulaire de timestamps utilisé
+// par shard pour calculer ProcessingRate, en remplacement de l'ancien
+// []time.Time non borné partagé par tout le processor.
+const detectionTimestampBufSize = 4096
+
+// cameraStats regroupe les compteurs et le dernier horodatage observé pour
+// une caméra donnée. Les compteurs sont mis à jour via sync/atomic pour ne
+// jamais bloquer un writer concurrent sur une autre caméra du même shard.
+type cameraStats struct {
+	totalDetections  int64 // atomic
+	totalAlerts      int64 // atomic
+	lastSeenUnixNano int64 // atomic
+}
+
+// detectionShard regroupe les détecteurs et les métriques d'un sous-ensemble
+// de caméras, déterminé par hash(cameraID) % detectionShardCount. Chaque
+// shard a son propre verrou de détecteurs et son propre buffer circulaire de
+// timestamps, indépendants de ceux des autres shards. queue est le channel
+// borné à travers lequel un unique worker goroutine (voir
+// eventProcessor.shardWorker) traite séquentiellement les détections de ce
+// shard, pour que son débit ne dépende jamais d'un autre shard.
+type detectionShard struct {
+	id int
+
+	detectorsMutex sync.RWMutex
+	detectors      map[string]Detector
+
+	totalDetections int64 // atomic
+	totalAlerts     int64 // atomic
+
+	tsMutex sync.Mutex
+	tsBuf   [detectionTimestampBufSize]time.Time
+	tsNext  int
+	tsCount int
+
+	camerasMutex sync.RWMutex
+	cameras      map[string]*cameraStats
+
+	queue chan detectionJob
+}
+
+func newDetectionShard(id, queueSize int) *detectionShard {
+	return &detectionShard{
+		id:        id,
+		detectors: make(map[string]Detector),
+		cameras:   make(map[string]*cameraStats),
+		queue:     make(chan detectionJob, queueSize),
+	}
+}
+
+// newDetectionShards crée les detectionShardCount shards utilisés par un
+// eventProcessor, chacun avec une file bornée à queueSize.
+func newDetectionShards(queueSize int) []*detectionShard {
+	shards := make([]*detectionShard, detectionShardCount)
+	for i := range shards {
+		shards[i] = newDetectionShard(i, queueSize)
+	}
+	return shards
+}
+
+// shardFor retourne le shard responsable de cameraID.
+func shardFor(shards []*detectionShard, cameraID string) *detectionShard {
+	h := fnv.New32a()
+	h.Write([]byte(cameraID))
+	return shards[h.Sum32()%uint32(len(shards))]
+}
+
+// recordDetection incrémente les compteurs atomiques du shard, pousse un
+// timestamp dans le buffer circulaire et met à jour les statistiques de
+// cameraID.
+func (s *detectionShard) recordDetection(cameraID string, at time.Time) {
+	atomic.AddInt64(&s.totalDetections, 1)
+
+	s.tsMutex.Lock()
+	s.tsBuf[s.tsNext] = at
+	s.tsNext = (s.tsNext + 1) % detectionTimestampBufSize
+	if s.tsCount < detectionTimestampBufSize {
+		s.tsCount++
+	}
+	s.tsMutex.Unlock()
+
+	cam := s.cameraStatsFor(cameraID)
+	atomic.AddInt64(&cam.totalDetections, 1)
+	atomic.StoreInt64(&cam.lastSeenUnixNano, at.UnixNano())
+}
+
+// recordAlert incrémente les compteurs d'alertes du shard et de cameraID.
+func (s *detectionShard) recordAlert(cameraID string) {
+	atomic.AddInt64(&s.totalAlerts, 1)
+	cam := s.cameraStatsFor(cameraID)
+	atomic.AddInt64(&cam.totalAlerts, 1)
+}
+
+func (s *detectionShard) cameraStatsFor(cameraID string) *cameraStats {
+	s.camerasMutex.RLock()
+	cam, ok := s.cameras[cameraID]
+	s.camerasMutex.RUnlock()
+	if ok {
+		return cam
+	}
+
+	s.camerasMutex.Lock()
+	defer s.camerasMutex.Unlock()
+	if cam, ok := s.cameras[cameraID]; ok {
+		return cam
+	}
+	cam = &cameraStats{}
+	s.cameras[cameraID] = cam
+	return cam
+}
+
+func (s *detectionShard) lookupCameraStats(cameraID string) (*cameraStats, bool) {
+	s.camerasMutex.RLock()
+	defer s.camerasMutex.RUnlock()
+	cam, ok := s.cameras[cameraID]
+	return cam, ok
+}
+
+// recentCount compte les timestamps du buffer circulaire postérieurs à
+// cutoff. Verrouille seulement le temps de parcourir le buffer du shard, pas
+// les autres shards.
+func (s *detectionShard) recentCount(cutoff time.Time) int {
+	s.tsMutex.Lock()
+	defer s.tsMutex.Unlock()
+
+	count := 0
+	for i := 0; i < s.tsCount; i++ {
+		if s.tsBuf[i].After(cutoff) {
+			count++
+		}
+	}
+	return count
+}
@@ -298,6 +298,7 @@ func isValidAlertType(alertType AlertType) bool {
 		AlertTypeIntrusion: true,
 		AlertTypeFace:      true,
 		AlertTypeSystem:    true,
+		AlertTypeIntel:     true,
 	}
 	return validTypes[alertType]
 }
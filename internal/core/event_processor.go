@@ -2,19 +2,162 @@
 package core
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"log"
+	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"surveillance-core/internal/logging"
+	"surveillance-core/internal/metrics"
+
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// tracer démarre les spans du pipeline de détection, de l'entrée dans
+// ProcessDetectionCtx jusqu'au dispatch des alertes produites (voir
+// internal/telemetry pour l'installation du TracerProvider global).
+var tracer = otel.Tracer("surveillance-core/event_processor")
+
 type EventProcessor interface {
 	ProcessDetection(detection Detection) []Alert
+	// ProcessDetectionCtx fait tout ce que ProcessDetection fait, avec en
+	// plus un span OpenTelemetry racine propagé à travers l'évaluation des
+	// détecteurs et le dispatch des alertes produites (voir
+	// internal/telemetry pour l'installation du TracerProvider).
+	ProcessDetectionCtx(ctx context.Context, detection Detection) []Alert
 	RegisterDetector(name string, detector Detector)
+	UnregisterDetector(name string) error
+	ListDetectors() []string
 	SetAlertCallback(callback func(Alert))
 	GetStats() ProcessorStats
+	// GetCameraStats retourne les statistiques d'une caméra donnée (taux de
+	// détection/alerte, dernier horodatage vu). Le second résultat est faux
+	// si la caméra n'a encore produit aucune détection.
+	GetCameraStats(cameraID string) (CameraStats, bool)
+	// LoadRules (re)charge le moteur de règles d'alerte depuis un flux
+	// YAML/JSON. Tant qu'aucune règle n'est chargée, chaque détecteur qui
+	// déclenche continue de produire directement une alerte (comportement
+	// historique).
+	LoadRules(r io.Reader) error
+	// Subscribe abonne un consommateur externe (NDJSON, gRPC) au flux de
+	// détections/alertes, filtré par EventFilter.
+	Subscribe(filter EventFilter) (events <-chan StreamEvent, errs <-chan error, cancel func())
+	// ReplayEvents rejoue les événements du ring-buffer interne postérieurs
+	// à sinceCursor, pour un abonné qui reprend après déconnexion.
+	ReplayEvents(sinceCursor int64) []StreamEvent
+	// SetVisionHealthy bascule le processor en pause (healthy == false) :
+	// tant que le service vision est injoignable, ProcessDetection n'exécute
+	// plus aucun détecteur et ne produit aucune alerte, pour éviter de juger
+	// sur des flux de détection potentiellement dégradés ou absents. Appelé
+	// par vision.GRPCClientOptions.OnHealthChange (voir internal/vision/grpc_client.go).
+	SetVisionHealthy(healthy bool)
+	// SetLogger remplace le logger utilisé pour les lignes émises pendant
+	// ProcessDetection (alertes générées, pause/reprise du service vision) ;
+	// à défaut, logging.Logger est utilisé.
+	SetLogger(logger *logging.Entry)
+	// ProcessDetectionAsync soumet detection au shard responsable sans
+	// attendre le résultat, pour les appelants à fort débit qui ne veulent
+	// pas bloquer sur le traitement (dispatch des détecteurs, du moteur de
+	// règles et des alertes). Le DetectionFuture renvoyé donne accès au
+	// résultat via Wait.
+	ProcessDetectionAsync(ctx context.Context, detection Detection) *DetectionFuture
+	// Close arrête les workers internes (shards et dispatch d'alertes) :
+	// chaque file est fermée puis drainée avant de retourner, borné par ctx.
+	// Les soumissions postérieures à Close (submit, ProcessDetection*) sont
+	// rejetées comme si le shard visé était fermé. À appeler au plus une
+	// fois, au shutdown du serveur (voir cmd/server/main.go).
+	Close(ctx context.Context) error
+}
+
+// DropPolicy décrit le comportement d'un shard dont la file est pleine : un
+// appelant plus rapide que le débit de traitement du shard ne doit jamais
+// bloquer indéfiniment tout le pipeline de détection à cause d'une seule
+// caméra.
+type DropPolicy string
+
+const (
+	// DropPolicyBlock fait attendre l'appelant jusqu'à ce qu'une place se
+	// libère dans la file du shard (comportement historique).
+	DropPolicyBlock DropPolicy = "block"
+	// DropPolicyDropOldest retire la plus ancienne détection en attente du
+	// shard pour faire de la place à la nouvelle.
+	DropPolicyDropOldest DropPolicy = "drop-oldest"
+	// DropPolicyDropNewest rejette la détection qui vient d'être soumise,
+	// en laissant la file du shard inchangée.
+	DropPolicyDropNewest DropPolicy = "drop-newest"
+)
+
+// EventProcessorConfig pilote la taille des files de détection par shard et
+// la politique appliquée quand l'une d'elles est pleine (voir
+// NewEventProcessorWithConfig).
+type EventProcessorConfig struct {
+	QueueSize  int
+	DropPolicy DropPolicy
+}
+
+// DefaultEventProcessorConfig renvoie la configuration utilisée par
+// NewEventProcessor et NewEventProcessorEmpty : une file de 256 détections
+// par shard, et un appelant qui attend plutôt que de perdre des détections.
+func DefaultEventProcessorConfig() EventProcessorConfig {
+	return EventProcessorConfig{
+		QueueSize:  256,
+		DropPolicy: DropPolicyBlock,
+	}
+}
+
+// detectionJob est l'unité de travail soumise à un detectionShard par
+// submit : ctx porte le span OpenTelemetry de l'appelant à travers la
+// frontière de goroutine, et reply (s'il n'est pas nil) reçoit le résultat
+// une fois le traitement terminé, ou dès que le job est évincé sans avoir
+// été traité (voir DropPolicyDropOldest).
+type detectionJob struct {
+	ctx       context.Context
+	detection Detection
+	reply     chan detectionResult
+}
+
+// detectionResult est ce que reçoit reply : alerts si la détection a
+// effectivement traversé le pipeline, ou err si elle a été abandonnée avant
+// (ErrDetectionDropped).
+type detectionResult struct {
+	alerts []Alert
+	err    error
+}
+
+// DetectionFuture est le résultat d'un ProcessDetectionAsync : Wait bloque
+// jusqu'à ce que le shard responsable ait traité la détection, ou jusqu'à
+// l'annulation de ctx.
+type DetectionFuture struct {
+	reply   chan detectionResult
+	dropped bool
+}
+
+// ErrDetectionDropped est renvoyée par DetectionFuture.Wait quand la
+// détection a été rejetée avant traitement (DropPolicyDropNewest, ou
+// DropPolicyDropOldest l'ayant elle-même évincée avant d'être traitée).
+var ErrDetectionDropped = fmt.Errorf("event_processor: détection rejetée (file du shard pleine)")
+
+// Wait attend le résultat du traitement de la détection à l'origine de ce
+// future. Renvoie ErrDetectionDropped si la détection n'a jamais été mise en
+// file (ou en a été évincée avant traitement), ou ctx.Err() si ctx est
+// annulé avant que le shard ait répondu.
+func (f *DetectionFuture) Wait(ctx context.Context) ([]Alert, error) {
+	if f.dropped {
+		return nil, ErrDetectionDropped
+	}
+	select {
+	case res := <-f.reply:
+		return res.alerts, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
 }
 
 type Detector interface {
@@ -22,6 +165,18 @@ type Detector interface {
 	CreateAlert(detection Detection) Alert
 }
 
+// StatefulDetector est un Detector optionnel qui garde un état entre les
+// détections (par caméra, par exemple). Init est appelé au moment de
+// l'enregistrement et Close au désenregistrement, ce que l'interface
+// Detector de base ne peut pas exprimer. Les détecteurs chargés
+// dynamiquement depuis des plugins (voir plugin_loader.go) s'y conforment
+// typiquement.
+type StatefulDetector interface {
+	Detector
+	Init(ctx context.Context) error
+	Close() error
+}
+
 type ProcessorStats struct {
 	TotalDetections int64     `json:"total_detections"`
 	TotalAlerts     int64     `json:"total_alerts"`
@@ -29,119 +184,587 @@ type ProcessorStats struct {
 	ProcessingRate  float64   `json:"processing_rate"` // détections/seconde
 }
 
+// CameraStats est la vue par-caméra de ProcessorStats, renvoyée par
+// GetCameraStats.
+type CameraStats struct {
+	CameraID        string    `json:"camera_id"`
+	TotalDetections int64     `json:"total_detections"`
+	TotalAlerts     int64     `json:"total_alerts"`
+	LastSeen        time.Time `json:"last_seen"`
+}
+
+// alertDispatchWorkerCount fixe le nombre de goroutines qui appellent
+// alertCallback, indépendamment des detectionShardCount shardWorker : un
+// notifier lent ou en panne (withRetry dans NotifierRegistry.send peut
+// attendre plusieurs secondes) ne doit jamais stalling un shardWorker, sous
+// peine de bloquer toutes les caméras de ce shard derrière une seule alerte
+// (voir dispatchAlert).
+const alertDispatchWorkerCount = 8
+
+// alertDispatchQueueSize borne le nombre d'alertes en attente de dispatch :
+// au-delà, dispatchAlert les abandonne plutôt que de faire attendre le
+// shardWorker appelant (voir metrics.AlertDispatchDroppedTotal).
+const alertDispatchQueueSize = 2048
+
+// alertDispatchJob est l'unité de travail d'alertDispatchWorker : callback
+// est le alertCallback capturé au moment où handleDetection a produit alert,
+// pour dispatcher avec le callback en vigueur à cet instant même si
+// SetAlertCallback est appelé entre-temps.
+type alertDispatchJob struct {
+	alert    Alert
+	callback func(Alert)
+}
+
+// eventProcessor répartit détecteurs et métriques sur detectionShardCount
+// shards indexés par hash(cameraID), pour qu'un ProcessDetection sur une
+// caméra ne contende pas avec un ProcessDetection sur une autre caméra
+// (voir detection_shard.go). Chaque shard est possédé par un unique worker
+// goroutine (voir shardWorker) qui dépile sa file bornée : deux caméras du
+// même shard se mettent en file l'une derrière l'autre, mais jamais derrière
+// une caméra d'un autre shard. Le dispatch des alertes (alertCallback) est
+// lui-même découplé des shardWorker via alertDispatch : un notifier lent ne
+// doit jamais retarder l'évaluation des détections d'un shard (voir
+// dispatchAlert). ruleEngine et eventStream restent partagés : ce sont déjà
+// des structures internes à verrouillage fin, pas le goulot d'étranglement
+// visé par ce sharding.
 type eventProcessor struct {
-	detectors     map[string]Detector
+	shards []*detectionShard
+	config EventProcessorConfig
+
 	alertCallback func(Alert)
-	stats         ProcessorStats
-	mutex         sync.RWMutex
-	
-	// Métriques de performance
-	lastMinuteDetections []time.Time
+	callbackMutex sync.RWMutex
+
+	alertDispatch chan alertDispatchJob
+
+	loggerValue atomic.Value // *logging.Entry ; voir SetLogger/getLogger
+
+	lastProcessedUnixNano int64 // atomic
+
+	visionPaused int32 // atomic ; 1 = détecteurs en pause (service vision injoignable)
+
+	ruleEngine  *RuleEngine
+	eventStream *EventStream
+
+	// closeMutex protège closed : submit le lit pour rejeter toute
+	// soumission postérieure à Close, qui l'écrit avant de fermer les files
+	// que lisent shardWG/dispatchWG, pour qu'aucun send n'arrive jamais sur
+	// un channel déjà fermé.
+	closeMutex sync.RWMutex
+	closed     bool
+	shardWG    sync.WaitGroup
+	dispatchWG sync.WaitGroup
 }
 
 func NewEventProcessor() EventProcessor {
-	ep := &eventProcessor{
-		detectors:            make(map[string]Detector),
-		lastMinuteDetections: make([]time.Time, 0),
-	}
-	
+	ep := newEventProcessor(DefaultEventProcessorConfig())
+
 	// Enregistrement des détecteurs par défaut
 	ep.RegisterDetector("motion", &MotionDetector{})
 	ep.RegisterDetector("intrusion", &IntrusionDetector{})
-	
-	// Nettoyage périodique des métriques
-	go ep.cleanupMetrics()
-	
+
 	return ep
 }
 
+// NewEventProcessorEmpty crée un processor sans détecteurs par défaut,
+// utilisé par les tests unitaires pour isoler le comportement d'un
+// détecteur donné.
+func NewEventProcessorEmpty() EventProcessor {
+	return newEventProcessor(DefaultEventProcessorConfig())
+}
+
+// NewEventProcessorWithConfig crée un processor dont la taille de file et la
+// politique de saturation par shard sont celles de config, pour les
+// déploiements qui veulent ajuster la backpressure au débit réel de leurs
+// caméras plutôt qu'utiliser DefaultEventProcessorConfig.
+func NewEventProcessorWithConfig(config EventProcessorConfig) EventProcessor {
+	ep := newEventProcessor(config)
+	ep.RegisterDetector("motion", &MotionDetector{})
+	ep.RegisterDetector("intrusion", &IntrusionDetector{})
+	return ep
+}
+
+func newEventProcessor(config EventProcessorConfig) *eventProcessor {
+	ep := &eventProcessor{
+		shards:        newDetectionShards(config.QueueSize),
+		config:        config,
+		alertDispatch: make(chan alertDispatchJob, alertDispatchQueueSize),
+		ruleEngine:    NewRuleEngine(10000),
+		eventStream:   NewEventStream(1000, 64),
+	}
+
+	for _, shard := range ep.shards {
+		ep.shardWG.Add(1)
+		go ep.shardWorker(shard)
+	}
+
+	for i := 0; i < alertDispatchWorkerCount; i++ {
+		ep.dispatchWG.Add(1)
+		go ep.alertDispatchWorker()
+	}
+
+	return ep
+}
+
+// ProcessDetection implémente EventProcessor.ProcessDetection en démarrant
+// un contexte racine : préservé pour les appelants historiques qui n'ont
+// pas de context.Context sous la main (voir ProcessDetectionCtx).
 func (ep *eventProcessor) ProcessDetection(detection Detection) []Alert {
-	ep.mutex.Lock()
-	defer ep.mutex.Unlock()
-	
-	// Mise à jour des statistiques
-	ep.stats.TotalDetections++
-	ep.stats.LastProcessed = time.Now()
-	ep.lastMinuteDetections = append(ep.lastMinuteDetections, time.Now())
-	
+	return ep.ProcessDetectionCtx(context.Background(), detection)
+}
+
+// ProcessDetectionCtx implémente EventProcessor.ProcessDetectionCtx : voir
+// sa documentation sur l'interface. La détection est soumise au shard
+// responsable de CameraID et ProcessDetectionCtx bloque sur un channel de
+// réponse dédié, pour rendre le passage par le worker pool transparent aux
+// appelants historiques.
+func (ep *eventProcessor) ProcessDetectionCtx(ctx context.Context, detection Detection) []Alert {
+	if atomic.LoadInt32(&ep.visionPaused) != 0 {
+		return nil
+	}
+
+	reply := make(chan detectionResult, 1)
+	if !ep.submit(ctx, detection, reply) {
+		return nil
+	}
+
+	select {
+	case res := <-reply:
+		return res.alerts
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+// ProcessDetectionAsync implémente EventProcessor.ProcessDetectionAsync :
+// voir sa documentation sur l'interface.
+func (ep *eventProcessor) ProcessDetectionAsync(ctx context.Context, detection Detection) *DetectionFuture {
+	if atomic.LoadInt32(&ep.visionPaused) != 0 {
+		return &DetectionFuture{dropped: true}
+	}
+
+	reply := make(chan detectionResult, 1)
+	if !ep.submit(ctx, detection, reply) {
+		return &DetectionFuture{dropped: true}
+	}
+	return &DetectionFuture{reply: reply}
+}
+
+// submit met detection en file sur le shard responsable de CameraID, en
+// appliquant la DropPolicy configurée si ce shard est saturé. Renvoie faux
+// si detection a été rejetée sans être mise en file (reply ne recevra
+// alors jamais de réponse) ; ce cas couvre aussi bien un shard saturé
+// (DropPolicyDropNewest) qu'un processor déjà fermé par Close.
+func (ep *eventProcessor) submit(ctx context.Context, detection Detection, reply chan detectionResult) bool {
+	ep.closeMutex.RLock()
+	defer ep.closeMutex.RUnlock()
+	if ep.closed {
+		return false
+	}
+
+	shard := shardFor(ep.shards, detection.CameraID)
+	job := detectionJob{ctx: ctx, detection: detection, reply: reply}
+
+	switch ep.config.DropPolicy {
+	case DropPolicyDropNewest:
+		select {
+		case shard.queue <- job:
+			return true
+		default:
+			metrics.EventQueueDroppedTotal.WithLabelValues("drop-newest").Inc()
+			return false
+		}
+	case DropPolicyDropOldest:
+		for {
+			select {
+			case shard.queue <- job:
+				return true
+			default:
+			}
+			select {
+			case evicted := <-shard.queue:
+				metrics.EventQueueDroppedTotal.WithLabelValues("drop-oldest").Inc()
+				if evicted.reply != nil {
+					evicted.reply <- detectionResult{err: ErrDetectionDropped}
+				}
+			default:
+				// Le shard a été vidé par son worker entre les deux select
+				// ci-dessus (course bénigne avec shardWorker) : on cède la
+				// main avant de retenter plutôt que de tourner en boucle
+				// serrée en attendant qu'il se resature.
+				runtime.Gosched()
+			}
+		}
+	default: // DropPolicyBlock
+		shard.queue <- job
+		return true
+	}
+}
+
+// shardWorker est l'unique goroutine autorisée à lire shard.queue : elle
+// traite les détections de ce shard une par une, dans l'ordre de soumission,
+// sans jamais se disputer un verrou avec les détections d'un autre shard.
+// Elle sort quand Close ferme shard.queue, après en avoir drainé le contenu
+// restant.
+func (ep *eventProcessor) shardWorker(shard *detectionShard) {
+	defer ep.shardWG.Done()
+	for job := range shard.queue {
+		metrics.EventQueueDepth.WithLabelValues(fmt.Sprintf("%d", shard.id)).Set(float64(len(shard.queue)))
+		alerts := ep.handleDetection(job.ctx, shard, job.detection)
+		if job.reply != nil {
+			job.reply <- detectionResult{alerts: alerts}
+		}
+	}
+}
+
+// dispatchAlert soumet alert au pool de dispatch (alertDispatch), pour que
+// callback (withRetry envers potentiellement plusieurs notifiers, voir
+// NotifierRegistry.send) s'exécute hors du shardWorker appelant : un
+// notifier lent ou en panne ne doit jamais stalling le traitement des
+// détections d'un shard entier. Abandonne silencieusement (hormis la
+// métrique et le log) si alertDispatch est saturé, plutôt que de bloquer.
+func (ep *eventProcessor) dispatchAlert(alert Alert, callback func(Alert)) {
+	if callback == nil {
+		return
+	}
+
+	select {
+	case ep.alertDispatch <- alertDispatchJob{alert: alert, callback: callback}:
+	default:
+		metrics.AlertDispatchDroppedTotal.Inc()
+		ep.getLogger().WithFields(logging.CameraFields(alert.CameraID)).
+			WithField("alert_id", alert.ID).
+			Warn("⚠️ File de dispatch d'alertes saturée, alerte abandonnée")
+	}
+}
+
+// alertDispatchWorker est l'une des alertDispatchWorkerCount goroutines qui
+// appellent alertCallback, indépendamment des shardWorker (voir
+// dispatchAlert). Elle sort quand Close ferme alertDispatch, après en avoir
+// drainé le contenu restant.
+func (ep *eventProcessor) alertDispatchWorker() {
+	defer ep.dispatchWG.Done()
+	for job := range ep.alertDispatch {
+		job.callback(job.alert)
+	}
+}
+
+// Close implémente EventProcessor.Close : voir sa documentation sur
+// l'interface.
+func (ep *eventProcessor) Close(ctx context.Context) error {
+	ep.closeMutex.Lock()
+	if ep.closed {
+		ep.closeMutex.Unlock()
+		return nil
+	}
+	ep.closed = true
+	for _, shard := range ep.shards {
+		close(shard.queue)
+	}
+	ep.closeMutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		// alertDispatch ne doit être fermé qu'une fois tous les
+		// shardWorker sortis : ce sont les seuls producteurs de
+		// dispatchAlert, et fermer plus tôt ferait paniquer un shardWorker
+		// encore en train de drainer sa file.
+		ep.shardWG.Wait()
+		close(ep.alertDispatch)
+		ep.dispatchWG.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("arrêt de l'event processor non terminé avant expiration du délai: %w", ctx.Err())
+	}
+}
+
+// handleDetection exécute la partie du pipeline qui s'exécutait auparavant
+// directement dans ProcessDetectionCtx : évaluation des détecteurs du
+// shard, moteur de règles, et dispatch des alertes produites. Appelée
+// uniquement depuis le worker goroutine propriétaire de shard.
+func (ep *eventProcessor) handleDetection(ctx context.Context, shard *detectionShard, detection Detection) []Alert {
+	ctx, span := tracer.Start(ctx, "event_processor.process_detection",
+		trace.WithAttributes(
+			attribute.String("camera_id", detection.CameraID),
+			attribute.String("detection_type", string(detection.Type)),
+		),
+	)
+	defer span.End()
+
+	start := time.Now()
+	defer func() { metrics.DetectionProcessingDuration.Observe(time.Since(start).Seconds()) }()
+
+	now := time.Now()
+	atomic.StoreInt64(&ep.lastProcessedUnixNano, now.UnixNano())
+	metrics.DetectionsTotal.WithLabelValues(detection.CameraID, string(detection.Type)).Inc()
+
+	shard.recordDetection(detection.CameraID, now)
+
+	_, detectSpan := tracer.Start(ctx, "event_processor.evaluate_detectors")
+	// Test de chaque détecteur du shard, en conservant lesquels ont
+	// déclenché pour le moteur de règles.
+	shard.detectorsMutex.RLock()
+	fired := make(map[string]bool, len(shard.detectors))
+	triggeredDetectors := make(map[string]Detector, len(shard.detectors))
+	for name, detector := range shard.detectors {
+		if ep.runDetector(name, detector, detection) {
+			fired[name] = true
+			triggeredDetectors[name] = detector
+		}
+	}
+	shard.detectorsMutex.RUnlock()
+	detectSpan.End()
+
+	ep.eventStream.Publish(StreamEvent{
+		Kind:      StreamEventDetection,
+		Detection: &detection,
+		Timestamp: now,
+	})
+
+	alertCallback := ep.getAlertCallback()
+
+	_, dispatchSpan := tracer.Start(ctx, "event_processor.dispatch_alerts")
+	defer dispatchSpan.End()
+
 	var alerts []Alert
-	
-	// Test de chaque détecteur
-	for name, detector := range ep.detectors {
-		if detector.ShouldAlert(detection) {
+	if ep.ruleEngine != nil && ep.ruleEngine.HasRules() {
+		// Le moteur de règles remplace le déclenchement direct
+		// détecteur -> alerte : cooldowns, debounce N-of-M et conditions
+		// composites AND/OR décident seuls si une alerte doit sortir.
+		alerts = ep.ruleEngine.Evaluate(detection, fired, zoneIDsForDetection(detection))
+		for i := range alerts {
+			alerts[i].ID = uuid.New().String()
+			alerts[i].Timestamp = time.Now()
+			shard.recordAlert(detection.CameraID)
+			ep.getLogger().WithFields(logging.CameraFields(detection.CameraID)).
+				WithField("alert_id", alerts[i].ID).
+				Infof("Alert générée par règle: %s", alerts[i].Message)
+			ep.eventStream.Publish(StreamEvent{Kind: StreamEventAlert, Alert: &alerts[i], Timestamp: alerts[i].Timestamp})
+			ep.dispatchAlert(alerts[i], alertCallback)
+		}
+	} else {
+		// Comportement historique : chaque détecteur qui déclenche produit
+		// directement une alerte.
+		for name, detector := range triggeredDetectors {
 			alert := detector.CreateAlert(detection)
 			alert.ID = uuid.New().String()
 			alert.Timestamp = time.Now()
-			
+
 			alerts = append(alerts, alert)
-			ep.stats.TotalAlerts++
-			
-			log.Printf("Alert générée par %s: %s", name, alert.Message)
-			
-			// Callback vers WebSocket
-			if ep.alertCallback != nil {
-				ep.alertCallback(alert)
-			}
+			shard.recordAlert(detection.CameraID)
+
+			ep.getLogger().WithFields(logging.CameraFields(detection.CameraID)).
+				WithField("alert_id", alert.ID).
+				Infof("Alert générée par %s: %s", name, alert.Message)
+
+			ep.eventStream.Publish(StreamEvent{Kind: StreamEventAlert, Alert: &alert, Timestamp: alert.Timestamp})
+			ep.dispatchAlert(alert, alertCallback)
 		}
 	}
-	
+
 	return alerts
 }
 
+func (ep *eventProcessor) getAlertCallback() func(Alert) {
+	ep.callbackMutex.RLock()
+	defer ep.callbackMutex.RUnlock()
+	return ep.alertCallback
+}
+
+// zoneIDsForDetection extrait les identifiants de zone associés à une
+// détection, quand le pipeline amont (FrameAnalyzer) les renseigne dans ses
+// métadonnées sous la clé "zone_id".
+func zoneIDsForDetection(detection Detection) []string {
+	if zoneID, ok := detection.Metadata["zone_id"]; ok && zoneID != "" {
+		return []string{zoneID}
+	}
+	return nil
+}
+
+// LoadRules délègue au moteur de règles interne.
+func (ep *eventProcessor) LoadRules(r io.Reader) error {
+	return ep.ruleEngine.LoadRules(r)
+}
+
+// Subscribe délègue à l'EventStream interne.
+func (ep *eventProcessor) Subscribe(filter EventFilter) (<-chan StreamEvent, <-chan error, func()) {
+	return ep.eventStream.Subscribe(filter)
+}
+
+// ReplayEvents délègue à l'EventStream interne.
+func (ep *eventProcessor) ReplayEvents(sinceCursor int64) []StreamEvent {
+	return ep.eventStream.Replay(sinceCursor)
+}
+
+// runDetector exécute un détecteur en isolant le reste du pipeline d'un
+// panic éventuel (notamment pour les détecteurs chargés dynamiquement
+// depuis des plugins, voir plugin_loader.go).
+func (ep *eventProcessor) runDetector(name string, detector Detector, detection Detection) (shouldAlert bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("⚠️ Détecteur %s a paniqué: %v", name, r)
+			shouldAlert = false
+		}
+	}()
+	return detector.ShouldAlert(detection)
+}
+
+// RegisterDetector réplique detector sur chaque shard, pour que
+// ProcessDetection n'ait jamais à lire un shard voisin. Init() n'est appelé
+// qu'une fois, avant la réplication, puisque c'est la même instance de
+// detector qui est partagée par tous les shards.
 func (ep *eventProcessor) RegisterDetector(name string, detector Detector) {
-	ep.mutex.Lock()
-	defer ep.mutex.Unlock()
-	
-	ep.detectors[name] = detector
+	if stateful, ok := detector.(StatefulDetector); ok {
+		if err := stateful.Init(context.Background()); err != nil {
+			log.Printf("⚠️ Échec d'initialisation du détecteur %s: %v", name, err)
+			return
+		}
+	}
+
+	for _, shard := range ep.shards {
+		shard.detectorsMutex.Lock()
+		shard.detectors[name] = detector
+		shard.detectorsMutex.Unlock()
+	}
+	metrics.DetectorsRegistered.Set(float64(len(ep.ListDetectors())))
 	log.Printf("Détecteur enregistré: %s", name)
 }
 
+// UnregisterDetector retire un détecteur précédemment enregistré de chaque
+// shard, en appelant Close() s'il s'agit d'un StatefulDetector.
+func (ep *eventProcessor) UnregisterDetector(name string) error {
+	var detector Detector
+	exists := false
+
+	for _, shard := range ep.shards {
+		shard.detectorsMutex.Lock()
+		if d, ok := shard.detectors[name]; ok {
+			detector = d
+			exists = true
+			delete(shard.detectors, name)
+		}
+		shard.detectorsMutex.Unlock()
+	}
+
+	if !exists {
+		return ErrDetectorNotFound
+	}
+
+	if stateful, ok := detector.(StatefulDetector); ok {
+		if err := stateful.Close(); err != nil {
+			log.Printf("⚠️ Erreur à la fermeture du détecteur %s: %v", name, err)
+			return err
+		}
+	}
+
+	metrics.DetectorsRegistered.Set(float64(len(ep.ListDetectors())))
+	log.Printf("Détecteur désenregistré: %s", name)
+	return nil
+}
+
+// ListDetectors retourne le nom des détecteurs actuellement enregistrés.
+// Chaque shard porte le même ensemble de détecteurs, lire le premier suffit.
+func (ep *eventProcessor) ListDetectors() []string {
+	shard := ep.shards[0]
+	shard.detectorsMutex.RLock()
+	defer shard.detectorsMutex.RUnlock()
+
+	names := make([]string, 0, len(shard.detectors))
+	for name := range shard.detectors {
+		names = append(names, name)
+	}
+	return names
+}
+
 func (ep *eventProcessor) SetAlertCallback(callback func(Alert)) {
-	ep.mutex.Lock()
-	defer ep.mutex.Unlock()
-	
+	ep.callbackMutex.Lock()
+	defer ep.callbackMutex.Unlock()
+
 	ep.alertCallback = callback
 }
 
+// SetLogger implémente EventProcessor.SetLogger : voir sa documentation sur
+// l'interface.
+func (ep *eventProcessor) SetLogger(logger *logging.Entry) {
+	ep.loggerValue.Store(logger)
+}
+
+// getLogger retourne le logger courant, ou logging.Logger tant que
+// SetLogger n'a pas été appelé.
+func (ep *eventProcessor) getLogger() *logging.Entry {
+	if logger, ok := ep.loggerValue.Load().(*logging.Entry); ok && logger != nil {
+		return logger
+	}
+	return logging.Logger
+}
+
+// SetVisionHealthy implémente EventProcessor.SetVisionHealthy : voir sa
+// documentation sur l'interface.
+func (ep *eventProcessor) SetVisionHealthy(healthy bool) {
+	var value int32
+	if !healthy {
+		value = 1
+	}
+
+	if atomic.SwapInt32(&ep.visionPaused, value) == value {
+		return
+	}
+
+	if healthy {
+		ep.getLogger().Info("▶️ Service vision de nouveau joignable : reprise des détecteurs")
+	} else {
+		ep.getLogger().Info("⏸️ Service vision injoignable : détecteurs en pause")
+	}
+}
+
+// GetStats agrège les compteurs atomiques et buffers circulaires de chaque
+// shard, sans jamais prendre un verrou qui bloquerait ProcessDetection sur
+// un autre shard.
 func (ep *eventProcessor) GetStats() ProcessorStats {
-	ep.mutex.RLock()
-	defer ep.mutex.RUnlock()
-	
-	// Calcul du taux de traitement
 	now := time.Now()
 	cutoff := now.Add(-time.Minute)
+
+	var totalDetections, totalAlerts int64
 	recentDetections := 0
-	
-	for _, t := range ep.lastMinuteDetections {
-		if t.After(cutoff) {
-			recentDetections++
-		}
+	for _, shard := range ep.shards {
+		totalDetections += atomic.LoadInt64(&shard.totalDetections)
+		totalAlerts += atomic.LoadInt64(&shard.totalAlerts)
+		recentDetections += shard.recentCount(cutoff)
+	}
+
+	lastProcessedUnixNano := atomic.LoadInt64(&ep.lastProcessedUnixNano)
+	var lastProcessed time.Time
+	if lastProcessedUnixNano != 0 {
+		lastProcessed = time.Unix(0, lastProcessedUnixNano)
+	}
+
+	return ProcessorStats{
+		TotalDetections: totalDetections,
+		TotalAlerts:     totalAlerts,
+		LastProcessed:   lastProcessed,
+		ProcessingRate:  float64(recentDetections) / 60.0, // détections/seconde
 	}
-	
-	stats := ep.stats
-	stats.ProcessingRate = float64(recentDetections) / 60.0 // détections/seconde
-	
-	return stats
 }
 
-func (ep *eventProcessor) cleanupMetrics() {
-	ticker := time.NewTicker(time.Minute)
-	defer ticker.Stop()
-	
-	for range ticker.C {
-		ep.mutex.Lock()
-		cutoff := time.Now().Add(-time.Minute)
-		filtered := make([]time.Time, 0)
-		
-		for _, t := range ep.lastMinuteDetections {
-			if t.After(cutoff) {
-				filtered = append(filtered, t)
-			}
-		}
-		
-		ep.lastMinuteDetections = filtered
-		ep.mutex.Unlock()
+// GetCameraStats retourne les statistiques du shard responsable de
+// cameraID, sans affecter les autres shards.
+func (ep *eventProcessor) GetCameraStats(cameraID string) (CameraStats, bool) {
+	shard := shardFor(ep.shards, cameraID)
+	cam, ok := shard.lookupCameraStats(cameraID)
+	if !ok {
+		return CameraStats{}, false
 	}
+
+	return CameraStats{
+		CameraID:        cameraID,
+		TotalDetections: atomic.LoadInt64(&cam.totalDetections),
+		TotalAlerts:     atomic.LoadInt64(&cam.totalAlerts),
+		LastSeen:        time.Unix(0, atomic.LoadInt64(&cam.lastSeenUnixNano)),
+	}, true
 }
 
 // Détecteurs concrets
@@ -2,6 +2,9 @@
 package core
 
 import (
+	"context"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -120,10 +123,13 @@ func TestEventProcessor_ProcessDetection(t *testing.T) {
 func TestEventProcessor_AlertCallback(t *testing.T) {
 	ep := NewEventProcessorEmpty()
 
-	// Setup callback pour capturer les alertes
-	var capturedAlerts []Alert
+	// Le dispatch d'alerte tourne sur son propre pool de workers, découplé
+	// du shardWorker (voir dispatchAlert) : le callback peut donc encore
+	// s'exécuter après le retour de ProcessDetection, d'où le channel plutôt
+	// qu'un simple append observé immédiatement après l'appel.
+	captured := make(chan Alert, 1)
 	ep.SetAlertCallback(func(alert Alert) {
-		capturedAlerts = append(capturedAlerts, alert)
+		captured <- alert
 	})
 
 	// Ajouter un détecteur qui trigger
@@ -139,17 +145,17 @@ func TestEventProcessor_AlertCallback(t *testing.T) {
 	}
 
 	alerts := ep.ProcessDetection(detection)
-
-	// Vérifier que le callback a été appelé
-	if len(capturedAlerts) != len(alerts) {
-		t.Errorf("Expected callback to be called %d times, got %d", len(alerts), len(capturedAlerts))
+	if len(alerts) != 1 {
+		t.Fatalf("Expected 1 alert, got %d", len(alerts))
 	}
 
-	// Vérifier que l'alerte capturée correspond
-	if len(capturedAlerts) > 0 {
-		if capturedAlerts[0].CameraID != detection.CameraID {
-			t.Errorf("Expected alert camera ID %s, got %s", detection.CameraID, capturedAlerts[0].CameraID)
+	select {
+	case alert := <-captured:
+		if alert.CameraID != detection.CameraID {
+			t.Errorf("Expected alert camera ID %s, got %s", detection.CameraID, alert.CameraID)
 		}
+	case <-time.After(time.Second):
+		t.Error("Expected callback to be called once")
 	}
 }
 
@@ -203,6 +209,69 @@ func BenchmarkEventProcessor_ProcessDetection(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		ep.ProcessDetection(detection)
 	}
+
+	// Sous-benchmarks à nombre de producteurs croissant, pour montrer que le
+	// worker pool par shard (voir shardWorker dans event_processor.go) fait
+	// effectivement grimper le débit plutôt que de saturer sur une file
+	// unique. SetParallelism(producers) lance producers × GOMAXPROCS
+	// goroutines : "producers=N" ne compte N goroutines que pour
+	// GOMAXPROCS=1.
+	for _, producers := range []int{1, 8, 64, 512} {
+		b.Run(fmt.Sprintf("producers=%d", producers), func(b *testing.B) {
+			cameraIDs := make([]string, producers)
+			for i := range cameraIDs {
+				cameraIDs[i] = uuid.New().String()
+			}
+
+			b.ResetTimer()
+			b.SetParallelism(producers)
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					d := Detection{
+						ID:         uuid.New().String(),
+						CameraID:   cameraIDs[i%producers],
+						Type:       DetectionTypeMotion,
+						Confidence: 0.8,
+						Timestamp:  time.Now(),
+					}
+					ep.ProcessDetection(d)
+					i++
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkEventProcessor_ProcessDetectionParallel simule des dizaines de
+// caméras envoyant des détections concurremment, pour vérifier que le
+// sharding par cameraID (voir detection_shard.go) fait effectivement
+// grimper le débit avec GOMAXPROCS au lieu de saturer sur un verrou unique.
+func BenchmarkEventProcessor_ProcessDetectionParallel(b *testing.B) {
+	ep := NewEventProcessorEmpty()
+	ep.RegisterDetector("bench", &mockDetector{shouldAlert: true, alertLevel: AlertLevelInfo})
+
+	const cameraCount = 64
+	cameraIDs := make([]string, cameraCount)
+	for i := range cameraIDs {
+		cameraIDs[i] = uuid.New().String()
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			detection := Detection{
+				ID:         uuid.New().String(),
+				CameraID:   cameraIDs[i%cameraCount],
+				Type:       DetectionTypeMotion,
+				Confidence: 0.8,
+				Timestamp:  time.Now(),
+			}
+			ep.ProcessDetection(detection)
+			i++
+		}
+	})
 }
 
 // Test de concurrence
@@ -239,3 +308,186 @@ func TestEventProcessor_Concurrency(t *testing.T) {
 		t.Errorf("Expected 10 total detections, got %d", stats.TotalDetections)
 	}
 }
+
+// gateDetector bloque ShouldAlert jusqu'à ce que release soit fermé, en
+// signalant started au premier appel : utilisé pour occuper délibérément le
+// shardWorker d'une caméra pendant qu'un test soumet d'autres détections,
+// pour vérifier leur comportement pendant que la file s'accumule.
+type gateDetector struct {
+	started chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (g *gateDetector) ShouldAlert(detection Detection) bool {
+	g.once.Do(func() { close(g.started) })
+	<-g.release
+	return false
+}
+
+func (g *gateDetector) CreateAlert(detection Detection) Alert {
+	return Alert{}
+}
+
+func TestEventProcessor_ProcessDetectionAsync(t *testing.T) {
+	ep := NewEventProcessorEmpty()
+	ep.RegisterDetector("test", &mockDetector{shouldAlert: true, alertLevel: AlertLevelInfo})
+
+	detection := Detection{
+		ID:         uuid.New().String(),
+		CameraID:   "async_cam",
+		Type:       DetectionTypeMotion,
+		Confidence: 0.8,
+		Timestamp:  time.Now(),
+	}
+
+	future := ep.ProcessDetectionAsync(context.Background(), detection)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	alerts, err := future.Wait(ctx)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(alerts) != 1 {
+		t.Errorf("expected 1 alert, got %d", len(alerts))
+	}
+}
+
+func TestEventProcessor_DropPolicyDropNewest(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	ep := NewEventProcessorWithConfig(EventProcessorConfig{QueueSize: 1, DropPolicy: DropPolicyDropNewest})
+	ep.RegisterDetector("gate", &gateDetector{started: started, release: release})
+
+	cam := "drop_newest_cam"
+	mk := func() Detection {
+		return Detection{ID: uuid.New().String(), CameraID: cam, Type: DetectionTypeMotion, Confidence: 0.8, Timestamp: time.Now()}
+	}
+
+	// Occupe le worker du shard responsable de cam pour que les soumissions
+	// suivantes s'accumulent dans sa file plutôt que d'être traitées
+	// immédiatement.
+	go ep.ProcessDetection(mk())
+	<-started
+
+	queued := ep.ProcessDetectionAsync(context.Background(), mk())   // remplit la file (QueueSize=1)
+	dropped := ep.ProcessDetectionAsync(context.Background(), mk()) // file pleine : rejetée sans y être mise
+
+	if !dropped.dropped {
+		t.Fatal("expected the third submission to be dropped immediately, without being queued")
+	}
+	if _, err := dropped.Wait(context.Background()); err != ErrDetectionDropped {
+		t.Fatalf("expected ErrDetectionDropped, got %v", err)
+	}
+
+	close(release)
+
+	waitCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := queued.Wait(waitCtx); err != nil {
+		t.Fatalf("expected the queued job to complete once the gate is released, got %v", err)
+	}
+}
+
+func TestEventProcessor_DropPolicyDropOldest(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	ep := NewEventProcessorWithConfig(EventProcessorConfig{QueueSize: 1, DropPolicy: DropPolicyDropOldest})
+	ep.RegisterDetector("gate", &gateDetector{started: started, release: release})
+
+	cam := "drop_oldest_cam"
+	mk := func() Detection {
+		return Detection{ID: uuid.New().String(), CameraID: cam, Type: DetectionTypeMotion, Confidence: 0.8, Timestamp: time.Now()}
+	}
+
+	go ep.ProcessDetection(mk())
+	<-started
+
+	evicted := ep.ProcessDetectionAsync(context.Background(), mk())
+	last := ep.ProcessDetectionAsync(context.Background(), mk()) // évince `evicted` pour se faire une place
+
+	// Régression : evicted.Wait ne doit jamais bloquer indéfiniment, son job
+	// ayant été retiré de la file sans jamais avoir été traité.
+	waitCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := evicted.Wait(waitCtx); err != ErrDetectionDropped {
+		t.Fatalf("expected ErrDetectionDropped for the evicted job, got %v", err)
+	}
+
+	close(release)
+
+	lastCtx, lastCancel := context.WithTimeout(context.Background(), time.Second)
+	defer lastCancel()
+	if _, err := last.Wait(lastCtx); err != nil {
+		t.Fatalf("expected the surviving job to complete once the gate is released, got %v", err)
+	}
+}
+
+func TestEventProcessor_AlertDispatchDoesNotBlockShardWorker(t *testing.T) {
+	ep := NewEventProcessorEmpty()
+	ep.RegisterDetector("test", &mockDetector{shouldAlert: true, alertLevel: AlertLevelWarning})
+
+	blockCallback := make(chan struct{})
+	ep.SetAlertCallback(func(alert Alert) {
+		<-blockCallback
+	})
+
+	cam := "dispatch_cam"
+	mk := func() Detection {
+		return Detection{ID: uuid.New().String(), CameraID: cam, Type: DetectionTypeMotion, Confidence: 0.8, Timestamp: time.Now()}
+	}
+
+	// Si le dispatch d'alerte n'était pas découplé du shardWorker (voir
+	// dispatchAlert), la deuxième détection sur cette même caméra/shard
+	// resterait bloquée derrière l'alertCallback de la première, toujours en
+	// attente sur blockCallback.
+	processed := make(chan []Alert, 2)
+	for i := 0; i < 2; i++ {
+		go func() { processed <- ep.ProcessDetection(mk()) }()
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-processed:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("ProcessDetection %d should not block on a slow alertCallback", i)
+		}
+	}
+
+	close(blockCallback)
+}
+
+func TestEventProcessor_Close(t *testing.T) {
+	ep := NewEventProcessorEmpty()
+	ep.RegisterDetector("test", &mockDetector{shouldAlert: true, alertLevel: AlertLevelInfo})
+
+	detection := Detection{
+		ID:         uuid.New().String(),
+		CameraID:   "close_cam",
+		Type:       DetectionTypeMotion,
+		Confidence: 0.8,
+		Timestamp:  time.Now(),
+	}
+
+	if alerts := ep.ProcessDetection(detection); len(alerts) != 1 {
+		t.Fatalf("expected 1 alert before Close, got %d", len(alerts))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := ep.Close(ctx); err != nil {
+		t.Fatalf("expected a clean Close, got %v", err)
+	}
+
+	// Une soumission après Close doit être rejetée plutôt que de paniquer en
+	// écrivant sur un channel de shard déjà fermé.
+	if alerts := ep.ProcessDetection(detection); alerts != nil {
+		t.Fatalf("expected no alerts for a detection submitted after Close, got %v", alerts)
+	}
+
+	// Close doit être idempotent.
+	if err := ep.Close(ctx); err != nil {
+		t.Fatalf("expected a second Close to be a no-op, got %v", err)
+	}
+}
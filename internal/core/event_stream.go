@@ -0,0 +1,197 @@
+// internal/core/event_stream.go
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+// StreamEventKind distingue les types d'événements portés par EventStream.
+type StreamEventKind string
+
+const (
+	StreamEventDetection StreamEventKind = "detection"
+	StreamEventAlert     StreamEventKind = "alert"
+)
+
+// StreamEvent est l'enveloppe publiée sur l'EventStream, avec un curseur
+// monotone permettant aux abonnés tardifs de rejouer depuis un point donné
+// (Last-Event-ID côté NDJSON).
+type StreamEvent struct {
+	Cursor    int64           `json:"cursor"`
+	Kind      StreamEventKind `json:"kind"`
+	Detection *Detection      `json:"detection,omitempty"`
+	Alert     *Alert          `json:"alert,omitempty"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// EventFilter sélectionne un sous-ensemble d'événements pour un abonné.
+type EventFilter struct {
+	CameraID  string
+	AlertType AlertType
+	MinLevel  AlertLevel
+}
+
+var alertLevelRank = map[AlertLevel]int{
+	AlertLevelInfo:     0,
+	AlertLevelWarning:  1,
+	AlertLevelCritical: 2,
+	AlertLevelUrgent:   3,
+}
+
+func (f EventFilter) matches(event StreamEvent) bool {
+	if event.Kind == StreamEventAlert && event.Alert != nil {
+		if f.CameraID != "" && f.CameraID != event.Alert.CameraID {
+			return false
+		}
+		if f.AlertType != "" && f.AlertType != event.Alert.Type {
+			return false
+		}
+		if f.MinLevel != "" && alertLevelRank[event.Alert.Level] < alertLevelRank[f.MinLevel] {
+			return false
+		}
+		return true
+	}
+
+	if event.Kind == StreamEventDetection && event.Detection != nil {
+		if f.CameraID != "" && f.CameraID != event.Detection.CameraID {
+			return false
+		}
+		// Les filtres par type d'alerte / niveau ne s'appliquent qu'aux alertes.
+		return f.AlertType == "" && f.MinLevel == ""
+	}
+
+	return false
+}
+
+// maxConsecutiveDrops borne le nombre d'événements consécutifs perdus par un
+// abonné trop lent avant qu'il ne soit coupé avec ErrServiceUnavailable.
+const maxConsecutiveDrops = 50
+
+type eventSubscriber struct {
+	id       int64
+	filter   EventFilter
+	events   chan StreamEvent
+	errs     chan error
+	drops    int
+	canceled bool
+}
+
+// EventStream maintient un ring-buffer borné des événements récents et les
+// diffuse à des abonnés filtrés (NDJSON HTTP, gRPC), avec replay depuis un
+// curseur donné pour les abonnés tardifs. Un abonné dont le buffer reste
+// plein est coupé (ErrServiceUnavailable) plutôt que de ralentir
+// ProcessDetection.
+type EventStream struct {
+	mutex         sync.Mutex
+	ring          []StreamEvent
+	capacity      int
+	nextCursor    int64
+	subscribers   map[int64]*eventSubscriber
+	nextSubID     int64
+	subscriberBuf int
+}
+
+// NewEventStream crée un flux d'événements avec un ring-buffer de replay de
+// `capacity` entrées et un buffer par abonné de `subscriberBuf` événements.
+func NewEventStream(capacity, subscriberBuf int) *EventStream {
+	if capacity <= 0 {
+		capacity = 1000
+	}
+	if subscriberBuf <= 0 {
+		subscriberBuf = 64
+	}
+	return &EventStream{
+		capacity:      capacity,
+		subscriberBuf: subscriberBuf,
+		subscribers:   make(map[int64]*eventSubscriber),
+	}
+}
+
+// Publish ajoute un événement au ring-buffer et le distribue à tous les
+// abonnés dont le filtre correspond. Non-bloquant : les abonnés trop lents
+// perdent des événements plutôt que de bloquer l'appelant.
+func (es *EventStream) Publish(event StreamEvent) {
+	es.mutex.Lock()
+	defer es.mutex.Unlock()
+
+	es.nextCursor++
+	event.Cursor = es.nextCursor
+
+	es.ring = append(es.ring, event)
+	if len(es.ring) > es.capacity {
+		es.ring = es.ring[len(es.ring)-es.capacity:]
+	}
+
+	for id, sub := range es.subscribers {
+		if sub.canceled || !sub.filter.matches(event) {
+			continue
+		}
+
+		select {
+		case sub.events <- event:
+			sub.drops = 0
+		default:
+			sub.drops++
+			if sub.drops >= maxConsecutiveDrops {
+				es.disconnectLocked(id, ErrServiceUnavailable)
+			}
+		}
+	}
+}
+
+// Subscribe enregistre un nouvel abonné filtré et retourne son channel
+// d'événements, un channel d'erreur (signalé une fois avant fermeture en cas
+// de coupure pour lenteur), et une fonction d'annulation.
+func (es *EventStream) Subscribe(filter EventFilter) (events <-chan StreamEvent, errs <-chan error, cancel func()) {
+	es.mutex.Lock()
+	defer es.mutex.Unlock()
+
+	id := es.nextSubID
+	es.nextSubID++
+
+	sub := &eventSubscriber{
+		id:     id,
+		filter: filter,
+		events: make(chan StreamEvent, es.subscriberBuf),
+		errs:   make(chan error, 1),
+	}
+	es.subscribers[id] = sub
+
+	cancelFunc := func() {
+		es.mutex.Lock()
+		defer es.mutex.Unlock()
+		es.disconnectLocked(id, nil)
+	}
+
+	return sub.events, sub.errs, cancelFunc
+}
+
+func (es *EventStream) disconnectLocked(id int64, err error) {
+	sub, exists := es.subscribers[id]
+	if !exists || sub.canceled {
+		return
+	}
+	sub.canceled = true
+	if err != nil {
+		sub.errs <- err
+	}
+	close(sub.events)
+	close(sub.errs)
+	delete(es.subscribers, id)
+}
+
+// Replay retourne les événements du ring-buffer dont le curseur est
+// strictement supérieur à sinceCursor, dans l'ordre chronologique.
+func (es *EventStream) Replay(sinceCursor int64) []StreamEvent {
+	es.mutex.Lock()
+	defer es.mutex.Unlock()
+
+	result := make([]StreamEvent, 0, len(es.ring))
+	for _, event := range es.ring {
+		if event.Cursor > sinceCursor {
+			result = append(result, event)
+		}
+	}
+	return result
+}
@@ -0,0 +1,395 @@
+// internal/core/intel.go
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/google/uuid"
+)
+
+// decisionEntry est la forme échangée par chaque IntelSource, au format du
+// flux de décisions CrowdSec LAPI : un tableau JSON de {type, value,
+// duration}. Type identifie le champ de Detection.Metadata à comparer ("ip",
+// "face_hash", "license_plate", ...), Value la valeur refusée et Duration sa
+// durée de vie ("4h", "30m", ...) côté source.
+type decisionEntry struct {
+	Type     string `json:"type"`
+	Value    string `json:"value"`
+	Duration string `json:"duration"`
+}
+
+// intelCacheEntry est une décision mise en cache, avec sa date d'expiration
+// et si elle provient d'un override local (jamais expirée par un pull
+// distant qui ne la reconduit pas, voir mergeRemote).
+type intelCacheEntry struct {
+	expiresAt time.Time
+	local     bool
+}
+
+// IntelDetector promeut une Detection en Alert critique quand l'une de ses
+// métadonnées correspond à une décision en cache (IP, hash de visage, plaque
+// d'immatriculation refusées), sur le modèle du pattern de flux de décisions
+// de CrowdSec : des sources HTTP distantes tirées périodiquement, plus des
+// overrides locaux gérés par cscli (cmd/cscli). Implémente StatefulDetector :
+// Init démarre un pull par source, Close les arrête proprement.
+type IntelDetector struct {
+	cfg        IntelligenceConfig
+	httpClient *http.Client
+
+	mutex      sync.RWMutex
+	cache      map[string]map[string]intelCacheEntry // type -> value -> entry
+	lastPulled map[string]string                     // nom de source -> Last-Modified/ETag reçu
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewIntelDetector crée un IntelDetector pour cfg, sans démarrer le
+// rafraîchissement périodique : voir Init.
+func NewIntelDetector(cfg IntelligenceConfig) *IntelDetector {
+	return &IntelDetector{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		cache:      make(map[string]map[string]intelCacheEntry),
+		lastPulled: make(map[string]string),
+	}
+}
+
+// Init charge les overrides locaux, effectue un premier pull synchrone de
+// chaque source puis lance une goroutine de rafraîchissement par source dont
+// RefreshInterval est non nul.
+func (d *IntelDetector) Init(ctx context.Context) error {
+	runCtx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+
+	if d.cfg.OverridesPath != "" {
+		if err := d.loadOverrides(); err != nil {
+			log.Printf("⚠️ IntelDetector: overrides locaux non chargés (%s): %v", d.cfg.OverridesPath, err)
+		}
+
+		d.wg.Add(1)
+		go d.watchOverrides(runCtx)
+	}
+
+	for _, source := range d.cfg.Sources {
+		if err := d.pull(runCtx, source); err != nil {
+			log.Printf("⚠️ IntelDetector: pull initial de %s échoué: %v", source.Name, err)
+		}
+
+		if source.RefreshInterval <= 0 {
+			continue
+		}
+
+		d.wg.Add(1)
+		go d.refreshLoop(runCtx, source)
+	}
+
+	return nil
+}
+
+// Close arrête les goroutines de rafraîchissement et attend leur sortie.
+func (d *IntelDetector) Close() error {
+	if d.cancel != nil {
+		d.cancel()
+	}
+	d.wg.Wait()
+	return nil
+}
+
+// refreshLoop appelle pull(source) toutes les source.RefreshInterval jusqu'à
+// l'annulation de ctx.
+func (d *IntelDetector) refreshLoop(ctx context.Context, source IntelSource) {
+	defer d.wg.Done()
+
+	ticker := time.NewTicker(source.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := d.pull(ctx, source); err != nil {
+				log.Printf("⚠️ IntelDetector: pull de %s échoué: %v", source.Name, err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// pull interroge source.URL avec un en-tête If-Modified-Since positionné sur
+// la dernière valeur Last-Modified reçue (pull incrémental) et un
+// Authorization: Bearer si BearerToken est renseigné. 304 Not Modified
+// laisse le cache inchangé.
+func (d *IntelDetector) pull(ctx context.Context, source IntelSource) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source.URL, nil)
+	if err != nil {
+		return fmt.Errorf("requête invalide pour %s: %w", source.Name, err)
+	}
+	if source.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+source.BearerToken)
+	}
+	if lastModified := d.getLastPulled(source.Name); lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("appel de %s: %w", source.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s a répondu %d", source.Name, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("lecture de la réponse de %s: %w", source.Name, err)
+	}
+
+	var decisions []decisionEntry
+	if err := json.Unmarshal(body, &decisions); err != nil {
+		return fmt.Errorf("décodage de la réponse de %s: %w", source.Name, err)
+	}
+
+	d.mergeRemote(decisions, source.CacheTTL)
+
+	if lastModified := resp.Header.Get("Last-Modified"); lastModified != "" {
+		d.setLastPulled(source.Name, lastModified)
+	}
+
+	return nil
+}
+
+func (d *IntelDetector) getLastPulled(sourceName string) string {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	return d.lastPulled[sourceName]
+}
+
+func (d *IntelDetector) setLastPulled(sourceName, value string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.lastPulled[sourceName] = value
+}
+
+// mergeRemote ajoute ou rafraîchit dans le cache chaque entrée de decisions,
+// chacune expirant à la plus courte de sa propre Duration et de
+// defaultTTL. Une entrée distante absente de decisions reste en cache
+// jusqu'à son expiration. Les overrides locaux (entry.local == true) ne sont
+// jamais touchés par un pull distant.
+func (d *IntelDetector) mergeRemote(decisions []decisionEntry, defaultTTL time.Duration) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for _, decision := range decisions {
+		ttl := defaultTTL
+		if parsed, err := time.ParseDuration(decision.Duration); err == nil && parsed > 0 {
+			if ttl <= 0 || parsed < ttl {
+				ttl = parsed
+			}
+		}
+		if ttl <= 0 {
+			ttl = time.Hour
+		}
+
+		byValue, ok := d.cache[decision.Type]
+		if !ok {
+			byValue = make(map[string]intelCacheEntry)
+			d.cache[decision.Type] = byValue
+		}
+		if existing, ok := byValue[decision.Value]; ok && existing.local {
+			continue
+		}
+		byValue[decision.Value] = intelCacheEntry{expiresAt: time.Now().Add(ttl)}
+	}
+}
+
+// loadOverrides (re)lit cfg.OverridesPath (format {"ip": ["1.2.3.4"], ...},
+// écrit par cmd/cscli) et remplace les overrides locaux en cache. Un fichier
+// absent n'est pas une erreur : aucun override local n'est alors actif.
+func (d *IntelDetector) loadOverrides() error {
+	data, err := os.ReadFile(d.cfg.OverridesPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("lecture de %s: %w", d.cfg.OverridesPath, err)
+	}
+
+	var overrides map[string][]string
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return fmt.Errorf("décodage de %s: %w", d.cfg.OverridesPath, err)
+	}
+
+	d.replaceLocalOverrides(overrides)
+	return nil
+}
+
+// replaceLocalOverrides retire toutes les entrées locales actuelles du cache
+// puis y réinjecte overrides, sans toucher aux décisions distantes en cache
+// (entry.local == false).
+func (d *IntelDetector) replaceLocalOverrides(overrides map[string][]string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for _, byValue := range d.cache {
+		for value, entry := range byValue {
+			if entry.local {
+				delete(byValue, value)
+			}
+		}
+	}
+
+	for decisionType, values := range overrides {
+		byValue, ok := d.cache[decisionType]
+		if !ok {
+			byValue = make(map[string]intelCacheEntry)
+			d.cache[decisionType] = byValue
+		}
+		for _, value := range values {
+			byValue[value] = intelCacheEntry{local: true}
+		}
+	}
+}
+
+// watchOverrides surveille le répertoire de cfg.OverridesPath via fsnotify
+// (même stratégie que core.ConfigManager.Watch : surveiller le répertoire
+// plutôt que le fichier pour suivre un remplacement atomique) et recharge les
+// overrides locaux à chaque écriture déclenchée par cscli.
+func (d *IntelDetector) watchOverrides(ctx context.Context) {
+	defer d.wg.Done()
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Printf("⚠️ IntelDetector: surveillance des overrides désactivée: %v", err)
+		return
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(filepath.Dir(d.cfg.OverridesPath)); err != nil {
+		log.Printf("⚠️ IntelDetector: surveillance de %s impossible: %v", d.cfg.OverridesPath, err)
+		return
+	}
+
+	target := filepath.Clean(d.cfg.OverridesPath)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != target || event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			if err := d.loadOverrides(); err != nil {
+				log.Printf("⚠️ IntelDetector: rechargement des overrides ignoré: %v", err)
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("⚠️ IntelDetector: erreur de surveillance des overrides: %v", err)
+		}
+	}
+}
+
+// AddOverride ajoute ou remplace en mémoire une décision locale sans
+// expiration, indépendamment du cycle de pull des sources distantes. Elle
+// n'est pas écrite dans cfg.OverridesPath (le fichier que cscli modifie) et
+// disparaît au prochain rechargement de ce fichier.
+func (d *IntelDetector) AddOverride(decisionType, value string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	byValue, ok := d.cache[decisionType]
+	if !ok {
+		byValue = make(map[string]intelCacheEntry)
+		d.cache[decisionType] = byValue
+	}
+	byValue[value] = intelCacheEntry{local: true}
+}
+
+// RemoveOverride retire une décision locale précédemment ajoutée par
+// AddOverride. N'affecte pas les décisions obtenues par pull distant.
+func (d *IntelDetector) RemoveOverride(decisionType, value string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if byValue, ok := d.cache[decisionType]; ok {
+		if entry, exists := byValue[value]; exists && entry.local {
+			delete(byValue, value)
+		}
+	}
+}
+
+// match retourne la valeur de detection.Metadata[decisionType] en cache
+// (local ou distant, non expiré) si elle existe, pour decisionType parmi
+// "ip", "face_hash", "license_plate".
+func (d *IntelDetector) match(detection Detection) (decisionType, value string, ok bool) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	now := time.Now()
+	for decisionType, byValue := range d.cache {
+		value, exists := detection.Metadata[decisionType]
+		if !exists || value == "" {
+			continue
+		}
+		entry, matched := byValue[value]
+		if !matched {
+			continue
+		}
+		if !entry.local && !entry.expiresAt.IsZero() && now.After(entry.expiresAt) {
+			continue
+		}
+		return decisionType, value, true
+	}
+	return "", "", false
+}
+
+// ShouldAlert implémente Detector : voir match.
+func (d *IntelDetector) ShouldAlert(detection Detection) bool {
+	_, _, ok := d.match(detection)
+	return ok
+}
+
+// CreateAlert implémente Detector. Le niveau est toujours
+// AlertLevelCritical : une correspondance sur une liste de vigilance
+// partagée est par construction une détection à haut risque, indépendamment
+// du type ou de la confiance de la Detection sous-jacente.
+func (d *IntelDetector) CreateAlert(detection Detection) Alert {
+	decisionType, value, _ := d.match(detection)
+
+	return Alert{
+		ID:        uuid.New().String(),
+		CameraID:  detection.CameraID,
+		Type:      AlertTypeIntel,
+		Level:     AlertLevelCritical,
+		Message:   fmt.Sprintf("Correspondance avec une liste de vigilance distante: %s=%s", decisionType, value),
+		Detection: &detection,
+		Timestamp: time.Now(),
+		Metadata: map[string]string{
+			"decision_type":  decisionType,
+			"decision_value": value,
+		},
+	}
+}
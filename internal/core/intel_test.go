@@ -0,0 +1,86 @@
+// internal/core/intel_test.go
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIntelDetector_PullAndMatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("expected bearer token header, got %q", got)
+		}
+		json.NewEncoder(w).Encode([]decisionEntry{
+			{Type: "ip", Value: "1.2.3.4", Duration: "1h"},
+		})
+	}))
+	defer server.Close()
+
+	detector := NewIntelDetector(IntelligenceConfig{
+		Sources: []IntelSource{{Name: "test", URL: server.URL, BearerToken: "test-token"}},
+	})
+	if err := detector.Init(context.Background()); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	defer detector.Close()
+
+	match := Detection{CameraID: "cam1", Metadata: map[string]string{"ip": "1.2.3.4"}}
+	if !detector.ShouldAlert(match) {
+		t.Error("expected match on cached IP decision")
+	}
+
+	alert := detector.CreateAlert(match)
+	if alert.Level != AlertLevelCritical {
+		t.Errorf("expected critical level, got %s", alert.Level)
+	}
+	if alert.Type != AlertTypeIntel {
+		t.Errorf("expected AlertTypeIntel, got %s", alert.Type)
+	}
+
+	noMatch := Detection{CameraID: "cam1", Metadata: map[string]string{"ip": "5.6.7.8"}}
+	if detector.ShouldAlert(noMatch) {
+		t.Error("did not expect match for unlisted IP")
+	}
+}
+
+func TestIntelDetector_LocalOverridesSurviveRemoteMerge(t *testing.T) {
+	detector := NewIntelDetector(IntelligenceConfig{})
+	detector.AddOverride("face_hash", "abc123")
+
+	detector.mergeRemote([]decisionEntry{{Type: "face_hash", Value: "def456", Duration: "1h"}}, 0)
+
+	if !detector.ShouldAlert(Detection{Metadata: map[string]string{"face_hash": "abc123"}}) {
+		t.Error("expected local override to remain after a remote merge")
+	}
+	if !detector.ShouldAlert(Detection{Metadata: map[string]string{"face_hash": "def456"}}) {
+		t.Error("expected remote decision to also match")
+	}
+
+	detector.RemoveOverride("face_hash", "abc123")
+	if detector.ShouldAlert(Detection{Metadata: map[string]string{"face_hash": "abc123"}}) {
+		t.Error("expected override to be gone after RemoveOverride")
+	}
+}
+
+func TestIntelDetector_LoadOverridesFromFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "overrides.json")
+	if err := os.WriteFile(path, []byte(`{"license_plate": ["AB-123-CD"]}`), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	detector := NewIntelDetector(IntelligenceConfig{OverridesPath: path})
+	if err := detector.loadOverrides(); err != nil {
+		t.Fatalf("loadOverrides: %v", err)
+	}
+
+	if !detector.ShouldAlert(Detection{Metadata: map[string]string{"license_plate": "AB-123-CD"}}) {
+		t.Error("expected override loaded from file to match")
+	}
+}
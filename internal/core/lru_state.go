@@ -0,0 +1,66 @@
+// internal/core/lru_state.go
+package core
+
+import (
+	"container/list"
+	"sync"
+)
+
+// lruState est un cache LRU borné en taille, utilisé par RuleEngine pour
+// garder l'état d'évaluation (cooldown, debounce) par (cameraID, ruleID)
+// sans que la mémoire ne croisse sans limite avec le nombre de caméras.
+type lruState struct {
+	capacity int
+	mutex    sync.Mutex
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+type lruEntry struct {
+	key   string
+	value *ruleState
+}
+
+func newLRUState(capacity int) *lruState {
+	if capacity <= 0 {
+		capacity = 10000
+	}
+	return &lruState{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (l *lruState) get(key string) *ruleState {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if elem, ok := l.items[key]; ok {
+		l.ll.MoveToFront(elem)
+		return elem.Value.(*lruEntry).value
+	}
+	return nil
+}
+
+func (l *lruState) put(key string, value *ruleState) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if elem, ok := l.items[key]; ok {
+		l.ll.MoveToFront(elem)
+		elem.Value.(*lruEntry).value = value
+		return
+	}
+
+	elem := l.ll.PushFront(&lruEntry{key: key, value: value})
+	l.items[key] = elem
+
+	if l.ll.Len() > l.capacity {
+		oldest := l.ll.Back()
+		if oldest != nil {
+			l.ll.Remove(oldest)
+			delete(l.items, oldest.Value.(*lruEntry).key)
+		}
+	}
+}
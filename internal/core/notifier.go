@@ -0,0 +1,442 @@
+// internal/core/notifier.go
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"text/template"
+	"time"
+
+	"surveillance-core/internal/logging"
+	"surveillance-core/internal/metrics"
+
+	"go.opentelemetry.io/otel"
+)
+
+// notifierTracer démarre les spans de dispatch d'alerte/rapport de session.
+// Ce sont des spans racines (context.Background()) : ils ne sont pas
+// rattachés au span event_processor.process_detection de la détection
+// d'origine (voir internal/telemetry pour l'installation du TracerProvider
+// global).
+var notifierTracer = otel.Tracer("surveillance-core/notifier")
+
+// Notifier est un backend de notification (WebSocket, email, webhook,
+// Slack/Discord...) capable d'envoyer une alerte individuelle ou un digest
+// de session. Name() identifie le backend dans les logs de démarrage
+// ("Using notifications: ...", à la Watchtower) et dans la configuration.
+type Notifier interface {
+	Name() string
+	Send(alert Alert) error
+	SendReport(report SessionReport) error
+}
+
+// SessionReport agrège les alertes produites pendant un cycle de scan
+// (fenêtre de temps ou taille de lot configurable), pour un digest
+// périodique plutôt qu'une notification par alerte.
+type SessionReport struct {
+	Start       time.Time          `json:"start"`
+	End         time.Time          `json:"end"`
+	Duration    time.Duration      `json:"duration"`
+	TotalAlerts int                `json:"total_alerts"`
+	ByLevel     map[AlertLevel]int `json:"by_level"`
+	ByType      map[AlertType]int  `json:"by_type"`
+	ByCamera    map[string]int     `json:"by_camera"`
+	Alerts      []Alert            `json:"alerts"`
+}
+
+// NotifierRegistryConfig pilote le comportement de batching de
+// NotifierRegistry.
+type NotifierRegistryConfig struct {
+	// ReportOnly bascule en mode digest : les alertes individuelles ne sont
+	// plus envoyées aux notifiers, seul SendReport est appelé à la
+	// clôture de chaque cycle.
+	ReportOnly bool
+	// BatchWindow et BatchSize définissent un cycle : le premier des deux
+	// seuils atteints clôture le SessionReport courant.
+	BatchWindow time.Duration
+	BatchSize   int
+}
+
+// NotifierRegistry fait le pont entre l'EventProcessor (un unique
+// alertCallback) et N backends de notification enregistrés dynamiquement,
+// et construit les SessionReport en accumulant les alertes d'un cycle.
+type NotifierRegistry struct {
+	mutex     sync.Mutex
+	notifiers []*registeredNotifier
+	config    NotifierRegistryConfig
+	current   SessionReport
+	wg        sync.WaitGroup
+
+	loggerValue atomic.Value // *logging.Entry ; voir SetLogger/getLogger
+}
+
+// registeredNotifier attache à un Notifier les filtres de routage et la
+// politique de résilience (retry + circuit breaker) du canal qui l'a
+// produit, pour que send/sendReport n'aient pas à refaire ce lien à chaque
+// appel.
+type registeredNotifier struct {
+	Notifier
+	filters []AlertFilter
+	retry   RetryConfig
+	breaker *circuitBreaker
+}
+
+// matchesFilters retourne true si alert doit être routée vers ce notifier :
+// aucun filtre enregistré = tout passe, sinon il suffit qu'un filtre de la
+// liste corresponde (sémantique OR, un canal peut vouloir plusieurs profils
+// d'alertes distincts).
+func (rn *registeredNotifier) matchesFilters(alert Alert) bool {
+	if len(rn.filters) == 0 {
+		return true
+	}
+	for _, f := range rn.filters {
+		if f.Matches(alert) {
+			return true
+		}
+	}
+	return false
+}
+
+// breakerState énumère les trois états du circuit breaker d'un notifier.
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// circuitBreaker coupe les envois vers un notifier qui échoue de façon
+// répétée, pour éviter de bloquer Dispatch sur un backend mort derrière
+// des tentatives de retry vouées à l'échec. S'ouvre après
+// BreakerConfig.FailureThreshold échecs consécutifs, repasse en half-open
+// après OpenDuration pour retenter un unique envoi probe.
+type circuitBreaker struct {
+	name   string
+	config BreakerConfig
+
+	mutex       sync.Mutex
+	state       breakerState
+	failures    int
+	openedUntil time.Time
+}
+
+func newCircuitBreaker(name string, config BreakerConfig) *circuitBreaker {
+	cb := &circuitBreaker{name: name, config: config}
+	metrics.NotifierBreakerState.WithLabelValues(name).Set(float64(breakerClosed))
+	return cb
+}
+
+// allow indique si un envoi peut être tenté maintenant, et fait transitionner
+// open -> half-open quand OpenDuration est écoulée.
+func (cb *circuitBreaker) allow() bool {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	switch cb.state {
+	case breakerOpen:
+		if time.Now().Before(cb.openedUntil) {
+			return false
+		}
+		cb.state = breakerHalfOpen
+		metrics.NotifierBreakerState.WithLabelValues(cb.name).Set(float64(breakerHalfOpen))
+		return true
+	case breakerHalfOpen:
+		return false
+	default:
+		return true
+	}
+}
+
+func (cb *circuitBreaker) recordSuccess() {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	cb.state = breakerClosed
+	cb.failures = 0
+	metrics.NotifierBreakerState.WithLabelValues(cb.name).Set(float64(breakerClosed))
+}
+
+func (cb *circuitBreaker) recordFailure() {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	if cb.state == breakerHalfOpen {
+		cb.state = breakerOpen
+		cb.openedUntil = time.Now().Add(cb.config.OpenDuration)
+		metrics.NotifierBreakerState.WithLabelValues(cb.name).Set(float64(breakerOpen))
+		return
+	}
+
+	cb.failures++
+	if cb.failures >= cb.config.FailureThreshold {
+		cb.state = breakerOpen
+		cb.openedUntil = time.Now().Add(cb.config.OpenDuration)
+		metrics.NotifierBreakerState.WithLabelValues(cb.name).Set(float64(breakerOpen))
+	}
+}
+
+// withRetry exécute op en respectant retry, avec un backoff exponentiel
+// borné par MaxBackoff entre chaque tentative. notifierName alimente
+// metrics.NotifyRetriesTotal, incrémenté à chaque tentative au-delà de la
+// première.
+func withRetry(notifierName string, retry RetryConfig, op func() error) error {
+	backoff := retry.InitialBackoff
+	var err error
+	for attempt := 1; attempt <= retry.MaxAttempts; attempt++ {
+		if err = op(); err == nil {
+			return nil
+		}
+		if attempt == retry.MaxAttempts {
+			break
+		}
+		metrics.NotifyRetriesTotal.WithLabelValues(notifierName).Inc()
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > retry.MaxBackoff {
+			backoff = retry.MaxBackoff
+		}
+	}
+	return err
+}
+
+// NewNotifierRegistry crée un registre vide et démarre son cycle de
+// batching ; les backends se greffent ensuite via Register.
+func NewNotifierRegistry(config NotifierRegistryConfig) *NotifierRegistry {
+	if config.BatchWindow <= 0 {
+		config.BatchWindow = 30 * time.Second
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 10
+	}
+
+	nr := &NotifierRegistry{config: config}
+	nr.resetReportLocked()
+
+	go nr.flushLoop()
+
+	return nr
+}
+
+// Register ajoute un backend de notification au registre. filters restreint
+// les alertes routées vers ce notifier (vide = toutes), retry et breaker
+// pilotent sa résilience ; une valeur zéro de retry ou breaker retombe sur
+// DefaultRetryConfig/DefaultBreakerConfig.
+func (nr *NotifierRegistry) Register(notifier Notifier, filters []AlertFilter, retry RetryConfig, breaker BreakerConfig) {
+	if retry.MaxAttempts <= 0 {
+		retry = DefaultRetryConfig()
+	}
+	if breaker.FailureThreshold <= 0 {
+		breaker = DefaultBreakerConfig()
+	}
+
+	nr.mutex.Lock()
+	defer nr.mutex.Unlock()
+	nr.notifiers = append(nr.notifiers, &registeredNotifier{
+		Notifier: notifier,
+		filters:  filters,
+		retry:    retry,
+		breaker:  newCircuitBreaker(notifier.Name(), breaker),
+	})
+}
+
+// SetLogger remplace le logger utilisé pour les lignes émises par send/
+// sendReport (circuit ouvert, échec d'envoi) ; à défaut, logging.Logger est
+// utilisé.
+func (nr *NotifierRegistry) SetLogger(logger *logging.Entry) {
+	nr.loggerValue.Store(logger)
+}
+
+// getLogger retourne le logger courant, ou logging.Logger tant que
+// SetLogger n'a pas été appelé.
+func (nr *NotifierRegistry) getLogger() *logging.Entry {
+	if logger, ok := nr.loggerValue.Load().(*logging.Entry); ok && logger != nil {
+		return logger
+	}
+	return logging.Logger
+}
+
+// GetNames retourne le nom des backends enregistrés, dans l'ordre
+// d'enregistrement (utilisé pour le log de démarrage "Using
+// notifications: ...").
+func (nr *NotifierRegistry) GetNames() []string {
+	nr.mutex.Lock()
+	defer nr.mutex.Unlock()
+
+	names := make([]string, len(nr.notifiers))
+	for i, n := range nr.notifiers {
+		names[i] = n.Name()
+	}
+	return names
+}
+
+// Dispatch doit être branché comme EventProcessor.SetAlertCallback : il
+// alimente toujours le SessionReport du cycle courant et, sauf en mode
+// ReportOnly, envoie aussi l'alerte individuellement à chaque backend.
+// Quand BatchSize est atteint, le cycle est clôturé immédiatement sans
+// attendre BatchWindow.
+func (nr *NotifierRegistry) Dispatch(alert Alert) {
+	nr.wg.Add(1)
+	defer nr.wg.Done()
+
+	nr.mutex.Lock()
+	nr.current.TotalAlerts++
+	nr.current.ByLevel[alert.Level]++
+	nr.current.ByType[alert.Type]++
+	nr.current.ByCamera[alert.CameraID]++
+	nr.current.Alerts = append(nr.current.Alerts, alert)
+
+	var report *SessionReport
+	if len(nr.current.Alerts) >= nr.config.BatchSize {
+		r := nr.closeReportLocked()
+		report = &r
+	}
+	nr.mutex.Unlock()
+
+	if report != nil {
+		nr.sendReport(*report)
+	}
+
+	if !nr.config.ReportOnly {
+		nr.send(alert)
+	}
+}
+
+// flushLoop clôture le cycle courant toutes les BatchWindow, même si
+// BatchSize n'a pas été atteint, pour que les digests restent réguliers.
+func (nr *NotifierRegistry) flushLoop() {
+	ticker := time.NewTicker(nr.config.BatchWindow)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		nr.wg.Add(1)
+		nr.mutex.Lock()
+		if len(nr.current.Alerts) == 0 {
+			nr.mutex.Unlock()
+			nr.wg.Done()
+			continue
+		}
+		report := nr.closeReportLocked()
+		nr.mutex.Unlock()
+
+		nr.sendReport(report)
+		nr.wg.Done()
+	}
+}
+
+func (nr *NotifierRegistry) closeReportLocked() SessionReport {
+	report := nr.current
+	report.End = time.Now()
+	report.Duration = report.End.Sub(report.Start)
+	nr.resetReportLocked()
+	return report
+}
+
+func (nr *NotifierRegistry) resetReportLocked() {
+	nr.current = SessionReport{
+		Start:    time.Now(),
+		ByLevel:  make(map[AlertLevel]int),
+		ByType:   make(map[AlertType]int),
+		ByCamera: make(map[string]int),
+	}
+}
+
+func (nr *NotifierRegistry) send(alert Alert) {
+	_, span := notifierTracer.Start(context.Background(), "notifier_registry.send")
+	defer span.End()
+
+	logger := nr.getLogger().WithFields(logging.AlertFields(alert.ID))
+	for _, n := range nr.snapshotNotifiers() {
+		if !n.matchesFilters(alert) {
+			continue
+		}
+		if !n.breaker.allow() {
+			logger.Infof("⚠️ Notifier %s: circuit ouvert, alerte abandonnée", n.Name())
+			continue
+		}
+		sendStart := time.Now()
+		err := withRetry(n.Name(), n.retry, func() error { return n.Send(alert) })
+		metrics.NotifyDuration.WithLabelValues(n.Name()).Observe(time.Since(sendStart).Seconds())
+		if err != nil {
+			n.breaker.recordFailure()
+			logger.WithError(err).Errorf("⚠️ Notifier %s: échec d'envoi d'alerte", n.Name())
+			continue
+		}
+		n.breaker.recordSuccess()
+	}
+}
+
+func (nr *NotifierRegistry) sendReport(report SessionReport) {
+	_, span := notifierTracer.Start(context.Background(), "notifier_registry.send_report")
+	defer span.End()
+
+	for _, n := range nr.snapshotNotifiers() {
+		if !n.breaker.allow() {
+			log.Printf("⚠️ Notifier %s: circuit ouvert, rapport de session abandonné", n.Name())
+			continue
+		}
+		sendStart := time.Now()
+		err := withRetry(n.Name(), n.retry, func() error { return n.SendReport(report) })
+		metrics.NotifyDuration.WithLabelValues(n.Name()).Observe(time.Since(sendStart).Seconds())
+		if err != nil {
+			n.breaker.recordFailure()
+			log.Printf("⚠️ Notifier %s: échec d'envoi du rapport de session: %v", n.Name(), err)
+			continue
+		}
+		n.breaker.recordSuccess()
+	}
+}
+
+func (nr *NotifierRegistry) snapshotNotifiers() []*registeredNotifier {
+	nr.mutex.Lock()
+	defer nr.mutex.Unlock()
+	return append([]*registeredNotifier(nil), nr.notifiers...)
+}
+
+// Drain attend que les envois de notification en cours se terminent, borné
+// par ctx, pour un arrêt propre du serveur (voir cmd/server/main.go).
+func (nr *NotifierRegistry) Drain(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		nr.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("délai d'attente dépassé pour l'arrêt du registre de notifications: %w", ctx.Err())
+	}
+}
+
+// LoadTemplate charge un template text/template depuis path, pour que les
+// opérateurs personnalisent le rendu des alertes et des digests sans
+// recompiler. Si path est vide, defaultText sert de gabarit par défaut afin
+// qu'un notifier reste utilisable sans configuration explicite.
+func LoadTemplate(path, defaultText string) (*template.Template, error) {
+	if path == "" {
+		return template.New("default").Parse(defaultText)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("lecture du template %s: %w", path, err)
+	}
+	return template.New(filepath.Base(path)).Parse(string(data))
+}
+
+// RenderTemplate applique tmpl à data et retourne le résultat sous forme de
+// texte.
+func RenderTemplate(tmpl *template.Template, data interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,92 @@
+// internal/core/notifier_test.go
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithRetry_SucceedsAfterTransientFailures(t *testing.T) {
+	attempts := 0
+	err := withRetry("test", RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}, func() error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("boom")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected success on the 3rd attempt, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestWithRetry_ExhaustsAttempts(t *testing.T) {
+	attempts := 0
+	wantErr := errors.New("persistent failure")
+	err := withRetry("test", RetryConfig{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}, func() error {
+		attempts++
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected the last error to be returned, got %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("expected exactly MaxAttempts=2 attempts, got %d", attempts)
+	}
+}
+
+func TestCircuitBreaker_OpensAfterThresholdAndHalfOpens(t *testing.T) {
+	cb := newCircuitBreaker("test", BreakerConfig{FailureThreshold: 2, OpenDuration: 20 * time.Millisecond})
+
+	if !cb.allow() {
+		t.Fatal("expected a fresh breaker to allow sends")
+	}
+	cb.recordFailure()
+	if !cb.allow() {
+		t.Fatal("expected breaker to still allow sends below the failure threshold")
+	}
+	cb.recordFailure()
+
+	if cb.allow() {
+		t.Fatal("expected breaker to be open after hitting the failure threshold")
+	}
+
+	time.Sleep(25 * time.Millisecond)
+	if !cb.allow() {
+		t.Fatal("expected breaker to transition to half-open once OpenDuration elapsed")
+	}
+}
+
+func TestCircuitBreaker_HalfOpenFailureReopens(t *testing.T) {
+	cb := newCircuitBreaker("test", BreakerConfig{FailureThreshold: 1, OpenDuration: 10 * time.Millisecond})
+
+	cb.recordFailure()
+	if cb.allow() {
+		t.Fatal("expected breaker to be open after a single failure at threshold 1")
+	}
+
+	time.Sleep(15 * time.Millisecond)
+	if !cb.allow() {
+		t.Fatal("expected breaker to allow one probe send in half-open state")
+	}
+
+	cb.recordFailure()
+	if cb.allow() {
+		t.Fatal("expected a half-open probe failure to reopen the breaker")
+	}
+}
+
+func TestCircuitBreaker_SuccessResetsFailureCount(t *testing.T) {
+	cb := newCircuitBreaker("test", BreakerConfig{FailureThreshold: 2, OpenDuration: 10 * time.Millisecond})
+
+	cb.recordFailure()
+	cb.recordSuccess()
+	cb.recordFailure()
+	if !cb.allow() {
+		t.Fatal("expected a success to reset the failure count so the breaker stays closed")
+	}
+}
@@ -0,0 +1,128 @@
+// internal/core/plugin_loader.go
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+	"plugin"
+	"sync"
+)
+
+// PluginABIVersion doit être retourné par le symbole exporté "PluginABIVersion"
+// de chaque plugin .so pour être chargé. Il est incrémenté à chaque
+// changement incompatible de l'interface Detector.
+const PluginABIVersion = 1
+
+// PluginLoader charge dynamiquement des détecteurs depuis des fichiers .so
+// via le package standard "plugin" et les enregistre auprès d'un
+// EventProcessor. Les plugins sont typiquement déposés dans un répertoire
+// "plugins/" surveillé par l'opérateur.
+type PluginLoader struct {
+	processor EventProcessor
+
+	mu     sync.Mutex
+	loaded map[string]string // nom du détecteur -> chemin du plugin
+}
+
+// NewPluginLoader crée un chargeur de plugins pour le processor donné.
+func NewPluginLoader(processor EventProcessor) *PluginLoader {
+	return &PluginLoader{
+		processor: processor,
+		loaded:    make(map[string]string),
+	}
+}
+
+// LoadFile ouvre un plugin .so, vérifie son ABI, instancie son détecteur via
+// le symbole exporté "NewDetector" et l'enregistre sous le nom donné. Tout
+// panic survenant pendant le chargement ou l'instanciation est intercepté
+// pour éviter qu'un plugin mal formé ne fasse tomber le serveur.
+func (pl *PluginLoader) LoadFile(name, path string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("plugin %s: panic au chargement: %v", path, r)
+		}
+	}()
+
+	p, openErr := plugin.Open(path)
+	if openErr != nil {
+		return fmt.Errorf("impossible d'ouvrir le plugin %s: %w", path, openErr)
+	}
+
+	versionSym, err := p.Lookup("PluginABIVersion")
+	if err != nil {
+		return fmt.Errorf("plugin %s: symbole PluginABIVersion manquant: %w", path, err)
+	}
+	version, ok := versionSym.(*int)
+	if !ok || *version != PluginABIVersion {
+		return fmt.Errorf("plugin %s: ABI incompatible (attendu %d)", path, PluginABIVersion)
+	}
+
+	factorySym, err := p.Lookup("NewDetector")
+	if err != nil {
+		return fmt.Errorf("plugin %s: symbole NewDetector manquant: %w", path, err)
+	}
+	factory, ok := factorySym.(func() Detector)
+	if !ok {
+		return fmt.Errorf("plugin %s: NewDetector a une signature inattendue", path)
+	}
+
+	detector := factory()
+	pl.processor.RegisterDetector(name, detector)
+
+	pl.mu.Lock()
+	pl.loaded[name] = path
+	pl.mu.Unlock()
+
+	return nil
+}
+
+// LoadDir charge tous les fichiers *.so du répertoire donné, en enregistrant
+// chaque détecteur sous le nom de base du fichier (sans extension). Les
+// erreurs de chargement individuelles sont retournées groupées mais
+// n'empêchent pas le chargement des autres plugins du répertoire.
+func (pl *PluginLoader) LoadDir(dir string) []error {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.so"))
+	if err != nil {
+		return []error{fmt.Errorf("lecture du répertoire de plugins %s: %w", dir, err)}
+	}
+
+	var errs []error
+	for _, path := range matches {
+		name := filepath.Base(path)
+		name = name[:len(name)-len(filepath.Ext(name))]
+
+		if err := pl.LoadFile(name, path); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// Unload désenregistre un détecteur précédemment chargé via ce loader.
+func (pl *PluginLoader) Unload(name string) error {
+	pl.mu.Lock()
+	_, exists := pl.loaded[name]
+	pl.mu.Unlock()
+	if !exists {
+		return ErrDetectorNotFound
+	}
+	if err := pl.processor.UnregisterDetector(name); err != nil {
+		return err
+	}
+	pl.mu.Lock()
+	delete(pl.loaded, name)
+	pl.mu.Unlock()
+	return nil
+}
+
+// Loaded retourne les plugins actuellement chargés (nom -> chemin du fichier).
+func (pl *PluginLoader) Loaded() map[string]string {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+	result := make(map[string]string, len(pl.loaded))
+	for k, v := range pl.loaded {
+		result[k] = v
+	}
+	return result
+}
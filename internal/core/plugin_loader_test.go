@@ -0,0 +1,76 @@
+// internal/core/plugin_loader_test.go
+package core
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// seedLoaded peuple directement pl.loaded (accès même-paquet) pour exercer
+// Unload/Loaded sans dépendre d'un vrai fichier .so, que plugin.Open ne sait
+// pas charger de façon portable dans un test unitaire.
+func seedLoaded(pl *PluginLoader, n int) []string {
+	names := make([]string, n)
+	pl.mu.Lock()
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("detector-%d", i)
+		names[i] = name
+		pl.loaded[name] = fmt.Sprintf("/plugins/%s.so", name)
+	}
+	pl.mu.Unlock()
+	return names
+}
+
+func TestPluginLoader_ConcurrentAccessDoesNotRace(t *testing.T) {
+	pl := NewPluginLoader(NewEventProcessorEmpty())
+	names := seedLoaded(pl, 20)
+
+	var wg sync.WaitGroup
+
+	// Unload : supprime les entrées seedées, en compétition avec Loaded et
+	// avec des LoadFile qui échouent (fichier inexistant) mais doivent tout
+	// de même rester sans race sur pl.loaded.
+	for _, name := range names {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			_ = pl.Unload(name)
+		}(name)
+	}
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_ = pl.LoadFile(fmt.Sprintf("bogus-%d", i), "/nonexistent/path.so")
+		}(i)
+	}
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = pl.Loaded()
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestPluginLoader_UnloadUnknownReturnsErrDetectorNotFound(t *testing.T) {
+	pl := NewPluginLoader(NewEventProcessorEmpty())
+	if err := pl.Unload("does-not-exist"); err != ErrDetectorNotFound {
+		t.Errorf("expected ErrDetectorNotFound, got %v", err)
+	}
+}
+
+func TestPluginLoader_LoadFile_BadPath(t *testing.T) {
+	pl := NewPluginLoader(NewEventProcessorEmpty())
+	if err := pl.LoadFile("bad", "/nonexistent/path.so"); err == nil {
+		t.Error("expected an error opening a nonexistent plugin file")
+	}
+	if len(pl.Loaded()) != 0 {
+		t.Error("expected a failed LoadFile to leave the loaded set untouched")
+	}
+}
@@ -0,0 +1,244 @@
+// internal/core/rule_engine.go
+package core
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RuleCondition décrit une condition composite sur les détecteurs qui ont
+// déclenché pour une détection donnée. Exactement un des champs Detector,
+// And ou Or doit être renseigné.
+type RuleCondition struct {
+	Detector string          `yaml:"detector,omitempty" json:"detector,omitempty"`
+	And      []RuleCondition `yaml:"and,omitempty" json:"and,omitempty"`
+	Or       []RuleCondition `yaml:"or,omitempty" json:"or,omitempty"`
+}
+
+func (c RuleCondition) eval(fired map[string]bool) bool {
+	switch {
+	case c.Detector != "":
+		return fired[c.Detector]
+	case len(c.And) > 0:
+		for _, sub := range c.And {
+			if !sub.eval(fired) {
+				return false
+			}
+		}
+		return true
+	case len(c.Or) > 0:
+		for _, sub := range c.Or {
+			if sub.eval(fired) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// DebounceConfig exprime une condition "N détections en M" avant de
+// considérer la règle satisfaite (ex: 3 détections personne en 10s).
+type DebounceConfig struct {
+	Count  int           `yaml:"count,omitempty" json:"count,omitempty"`
+	Window time.Duration `yaml:"window,omitempty" json:"window,omitempty"`
+}
+
+// ScheduleWindow restreint l'évaluation d'une règle à une plage horaire
+// (heure locale, 0-23). StartHour > EndHour signifie une plage traversant
+// minuit (ex: 22 -> 6).
+type ScheduleWindow struct {
+	StartHour int `yaml:"start_hour" json:"start_hour"`
+	EndHour   int `yaml:"end_hour" json:"end_hour"`
+}
+
+func (s ScheduleWindow) contains(t time.Time) bool {
+	hour := t.Hour()
+	if s.StartHour <= s.EndHour {
+		return hour >= s.StartHour && hour < s.EndHour
+	}
+	// Plage traversant minuit
+	return hour >= s.StartHour || hour < s.EndHour
+}
+
+// Rule décrit une règle d'alerte configurable : à quelle(s) caméra(s) et
+// zone(s) elle s'applique, la condition composite sur les détecteurs, un
+// cooldown par (caméra, règle), un debounce N-of-M, et une fenêtre horaire
+// optionnelle.
+type Rule struct {
+	ID         string          `yaml:"id" json:"id"`
+	Name       string          `yaml:"name" json:"name"`
+	CameraID   string          `yaml:"camera_id,omitempty" json:"camera_id,omitempty"` // "" ou "*" = toutes
+	ZoneIDs    []string        `yaml:"zones,omitempty" json:"zones,omitempty"`
+	Condition  RuleCondition   `yaml:"condition" json:"condition"`
+	Cooldown   time.Duration   `yaml:"cooldown,omitempty" json:"cooldown,omitempty"`
+	Debounce   DebounceConfig  `yaml:"debounce,omitempty" json:"debounce,omitempty"`
+	Schedule   *ScheduleWindow `yaml:"schedule,omitempty" json:"schedule,omitempty"`
+	AlertType  AlertType       `yaml:"alert_type,omitempty" json:"alert_type,omitempty"`
+	AlertLevel AlertLevel      `yaml:"alert_level,omitempty" json:"alert_level,omitempty"`
+	Message    string          `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+func (r Rule) matchesCamera(cameraID string) bool {
+	return r.CameraID == "" || r.CameraID == "*" || r.CameraID == cameraID
+}
+
+func (r Rule) matchesZones(detectionZones []string) bool {
+	if len(r.ZoneIDs) == 0 {
+		return true
+	}
+	for _, want := range r.ZoneIDs {
+		for _, have := range detectionZones {
+			if want == have {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ruleState est l'état d'évaluation conservé par (cameraID, ruleID).
+type ruleState struct {
+	lastFired time.Time
+	window    []time.Time
+}
+
+// RuleEngine évalue un ensemble de règles hot-reloadables sur les détecteurs
+// ayant déclenché pour une détection, avec cooldown, debounce N-of-M et
+// conditions composites AND/OR. L'état par règle est conservé dans une LRU
+// bornée pour que la mémoire reste constante quel que soit le nombre de
+// caméras.
+type RuleEngine struct {
+	mutex sync.RWMutex
+	rules []Rule
+	state *lruState
+}
+
+// NewRuleEngine crée un moteur de règles vide. maxState borne le nombre
+// d'entrées (cameraID, ruleID) conservées en mémoire.
+func NewRuleEngine(maxState int) *RuleEngine {
+	return &RuleEngine{
+		state: newLRUState(maxState),
+	}
+}
+
+// HasRules indique si au moins une règle est chargée.
+func (re *RuleEngine) HasRules() bool {
+	re.mutex.RLock()
+	defer re.mutex.RUnlock()
+	return len(re.rules) > 0
+}
+
+// LoadRules (re)charge l'ensemble des règles depuis un flux YAML ou JSON
+// (JSON est un sous-ensemble valide de YAML). Le remplacement est atomique :
+// les anciennes règles restent actives jusqu'à ce que le décodage réussisse.
+func (re *RuleEngine) LoadRules(r io.Reader) error {
+	var rules []Rule
+	if err := yaml.NewDecoder(r).Decode(&rules); err != nil {
+		return fmt.Errorf("décodage des règles: %w", err)
+	}
+
+	for i, rule := range rules {
+		if rule.ID == "" {
+			return fmt.Errorf("règle %d: id requis", i)
+		}
+	}
+
+	re.mutex.Lock()
+	re.rules = rules
+	re.mutex.Unlock()
+
+	return nil
+}
+
+// Evaluate évalue toutes les règles applicables à la détection donnée étant
+// donné l'ensemble des détecteurs qui ont déclenché (fired) et les zones
+// qu'elle intersecte, et retourne les alertes produites.
+func (re *RuleEngine) Evaluate(detection Detection, fired map[string]bool, zoneIDs []string) []Alert {
+	re.mutex.RLock()
+	rules := re.rules
+	re.mutex.RUnlock()
+
+	var alerts []Alert
+	now := detection.Timestamp
+
+	for _, rule := range rules {
+		if !rule.matchesCamera(detection.CameraID) || !rule.matchesZones(zoneIDs) {
+			continue
+		}
+		if !rule.Condition.eval(fired) {
+			continue
+		}
+		if rule.Schedule != nil && !rule.Schedule.contains(now) {
+			continue
+		}
+
+		key := detection.CameraID + "|" + rule.ID
+		state := re.state.get(key)
+		if state == nil {
+			state = &ruleState{}
+		}
+
+		if !state.lastFired.IsZero() && now.Sub(state.lastFired) < rule.Cooldown {
+			re.state.put(key, state)
+			continue
+		}
+
+		if rule.Debounce.Count > 1 {
+			state.window = append(state.window, now)
+			cutoff := now.Add(-rule.Debounce.Window)
+			filtered := state.window[:0]
+			for _, t := range state.window {
+				if t.After(cutoff) {
+					filtered = append(filtered, t)
+				}
+			}
+			state.window = filtered
+
+			if len(state.window) < rule.Debounce.Count {
+				re.state.put(key, state)
+				continue
+			}
+		}
+
+		state.lastFired = now
+		state.window = nil
+		re.state.put(key, state)
+
+		alerts = append(alerts, Alert{
+			CameraID:  detection.CameraID,
+			Type:      rule.alertTypeOrDefault(),
+			Level:     rule.alertLevelOrDefault(),
+			Message:   rule.messageOrDefault(detection),
+			Detection: &detection,
+		})
+	}
+
+	return alerts
+}
+
+func (r Rule) alertTypeOrDefault() AlertType {
+	if r.AlertType != "" {
+		return r.AlertType
+	}
+	return AlertTypeMotion
+}
+
+func (r Rule) alertLevelOrDefault() AlertLevel {
+	if r.AlertLevel != "" {
+		return r.AlertLevel
+	}
+	return AlertLevelInfo
+}
+
+func (r Rule) messageOrDefault(detection Detection) string {
+	if r.Message != "" {
+		return r.Message
+	}
+	return fmt.Sprintf("Règle '%s' déclenchée sur %s", r.Name, detection.CameraID)
+}
@@ -0,0 +1,152 @@
+// internal/core/rule_engine_test.go
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRuleEngine_LoadRules(t *testing.T) {
+	re := NewRuleEngine(16)
+
+	if err := re.LoadRules(strings.NewReader(`
+- id: r1
+  condition:
+    detector: motion
+`)); err != nil {
+		t.Fatalf("LoadRules: %v", err)
+	}
+	if !re.HasRules() {
+		t.Error("expected HasRules to be true after a successful load")
+	}
+
+	if err := re.LoadRules(strings.NewReader(`
+- name: missing-id
+  condition:
+    detector: motion
+`)); err == nil {
+		t.Error("expected an error for a rule without an id")
+	}
+	// Le rechargement raté ne doit pas écraser les règles déjà en place.
+	if !re.HasRules() {
+		t.Error("expected rules from the prior successful load to remain active")
+	}
+}
+
+func TestRuleEngine_Cooldown(t *testing.T) {
+	re := NewRuleEngine(16)
+	re.rules = []Rule{{
+		ID:        "r1",
+		Condition: RuleCondition{Detector: "motion"},
+		Cooldown:  10 * time.Second,
+	}}
+
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	det := Detection{CameraID: "cam1", Timestamp: base}
+	fired := map[string]bool{"motion": true}
+
+	if alerts := re.Evaluate(det, fired, nil); len(alerts) != 1 {
+		t.Fatalf("expected 1 alert on first fire, got %d", len(alerts))
+	}
+
+	det.Timestamp = base.Add(5 * time.Second)
+	if alerts := re.Evaluate(det, fired, nil); len(alerts) != 0 {
+		t.Fatalf("expected no alert inside cooldown window, got %d", len(alerts))
+	}
+
+	det.Timestamp = base.Add(11 * time.Second)
+	if alerts := re.Evaluate(det, fired, nil); len(alerts) != 1 {
+		t.Fatalf("expected 1 alert once cooldown elapsed, got %d", len(alerts))
+	}
+}
+
+func TestRuleEngine_DebounceNOfM(t *testing.T) {
+	re := NewRuleEngine(16)
+	re.rules = []Rule{{
+		ID:        "r1",
+		Condition: RuleCondition{Detector: "person"},
+		Debounce:  DebounceConfig{Count: 3, Window: 10 * time.Second},
+	}}
+
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	det := Detection{CameraID: "cam1"}
+	fired := map[string]bool{"person": true}
+
+	det.Timestamp = base
+	if alerts := re.Evaluate(det, fired, nil); len(alerts) != 0 {
+		t.Fatalf("expected no alert on 1st detection, got %d", len(alerts))
+	}
+
+	det.Timestamp = base.Add(2 * time.Second)
+	if alerts := re.Evaluate(det, fired, nil); len(alerts) != 0 {
+		t.Fatalf("expected no alert on 2nd detection, got %d", len(alerts))
+	}
+
+	det.Timestamp = base.Add(4 * time.Second)
+	if alerts := re.Evaluate(det, fired, nil); len(alerts) != 1 {
+		t.Fatalf("expected an alert on the 3rd detection inside the window, got %d", len(alerts))
+	}
+}
+
+func TestRuleEngine_DebounceWindowSlides(t *testing.T) {
+	re := NewRuleEngine(16)
+	re.rules = []Rule{{
+		ID:        "r1",
+		Condition: RuleCondition{Detector: "person"},
+		Debounce:  DebounceConfig{Count: 2, Window: 5 * time.Second},
+	}}
+
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	det := Detection{CameraID: "cam1"}
+	fired := map[string]bool{"person": true}
+
+	det.Timestamp = base
+	re.Evaluate(det, fired, nil)
+
+	// La 2e détection arrive après expiration de la fenêtre de la 1ère : elle
+	// ne doit pas être comptée comme encore valide dans la fenêtre glissante.
+	det.Timestamp = base.Add(10 * time.Second)
+	if alerts := re.Evaluate(det, fired, nil); len(alerts) != 0 {
+		t.Fatalf("expected no alert once the first detection has fallen out of the window, got %d", len(alerts))
+	}
+}
+
+func TestScheduleWindow_MidnightWraparound(t *testing.T) {
+	sched := ScheduleWindow{StartHour: 22, EndHour: 6}
+
+	inside := []int{22, 23, 0, 3, 5}
+	for _, h := range inside {
+		ts := time.Date(2026, 1, 1, h, 0, 0, 0, time.UTC)
+		if !sched.contains(ts) {
+			t.Errorf("expected hour %d to be inside the 22-6 window", h)
+		}
+	}
+
+	outside := []int{6, 12, 21}
+	for _, h := range outside {
+		ts := time.Date(2026, 1, 1, h, 0, 0, 0, time.UTC)
+		if sched.contains(ts) {
+			t.Errorf("expected hour %d to be outside the 22-6 window", h)
+		}
+	}
+}
+
+func TestRuleCondition_AndOr(t *testing.T) {
+	cond := RuleCondition{
+		Or: []RuleCondition{
+			{And: []RuleCondition{{Detector: "motion"}, {Detector: "person"}}},
+			{Detector: "intel_match"},
+		},
+	}
+
+	if cond.eval(map[string]bool{"motion": true}) {
+		t.Error("AND branch should require both detectors")
+	}
+	if !cond.eval(map[string]bool{"motion": true, "person": true}) {
+		t.Error("AND branch should be satisfied when both detectors fire")
+	}
+	if !cond.eval(map[string]bool{"intel_match": true}) {
+		t.Error("OR branch should be satisfied by the intel_match detector alone")
+	}
+}
@@ -34,6 +34,40 @@ type CameraConfig struct {
 	EnableMotion bool       `json:"enable_motion"`
 	EnableAI     bool       `json:"enable_ai"`
 	Zones        []Zone     `json:"zones"`
+	// VisionBackend sélectionne le backend de capture pour cette caméra
+	// ("mock", "rtsp", "grpc"). Vide = backend par défaut du service.
+	VisionBackend string `json:"vision_backend,omitempty"`
+	// Transport précise le transport RTP à utiliser pour les caméras
+	// RTSP/ONVIF ("tcp" ou "udp"). Vide = "tcp", plus robuste derrière NAT
+	// et pare-feu que UDP.
+	Transport string `json:"transport,omitempty"`
+	// PreferredCodec indique au backend de capture le codec à privilégier
+	// si la caméra en propose plusieurs ("h264", "h265"). Vide = premier
+	// codec supporté trouvé dans la description SDP.
+	PreferredCodec string `json:"preferred_codec,omitempty"`
+	// RemoteURL, si non vide, fédère cette caméra vers un service vision
+	// distant ("host:port") plutôt que le visionClient local de
+	// l'application, pour les déploiements qui répartissent leurs caméras
+	// sur plusieurs edge box. Hostname/Port sont une façon équivalente de le
+	// préciser quand host et port sont déjà gérés séparément ; RemoteURL a
+	// priorité si les deux sont renseignés.
+	RemoteURL string `json:"remote_url,omitempty"`
+	Hostname  string `json:"hostname,omitempty"`
+	Port      int    `json:"port,omitempty"`
+	// RtcpPort précise, pour les backends qui séparent le flux RTCP du flux
+	// RTP principal, le port à utiliser côté service vision distant. Vide =
+	// le service distant choisit lui-même son port RTCP.
+	RtcpPort int `json:"rtcp_port,omitempty"`
+	// RemoteToken est le jeton HS256 (voir vision.SignRemoteToken) attaché à
+	// chaque appel gRPC vers RemoteURL/Hostname. Vide = un jeton est signé à
+	// la volée à partir de Config.Security.JWTSecret, valable une heure (voir
+	// remoteTokenTTL dans internal/api/handlers.go).
+	RemoteToken string `json:"remote_token,omitempty"`
+	// RemoteTLS sécurise le canal gRPC vers RemoteURL/Hostname (voir
+	// vision.NewRemoteGRPCClient). Enabled == false laisse le canal en clair
+	// pour les déploiements intra-cluster qui n'en ont pas besoin, mais dans
+	// ce cas RemoteToken est alors envoyé sans chiffrement de transport.
+	RemoteTLS TLSCfg `json:"remote_tls,omitempty"`
 }
 
 type Resolution struct {
@@ -42,10 +76,14 @@ type Resolution struct {
 }
 
 type Zone struct {
-	ID     string    `json:"id"`
-	Name   string    `json:"name"`
-	Points []Point   `json:"points"` // Polygone de détection
-	Active bool      `json:"active"`
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Points []Point `json:"points"` // Polygone de détection
+	Active bool    `json:"active"`
+	// Tag classe la zone pour le hit-testing ("intrusion", "restricted", ...).
+	// Une détection qui intersecte une zone taguée "intrusion" devient une
+	// Detection de type Intrusion.
+	Tag string `json:"tag,omitempty"`
 }
 
 type Point struct {
@@ -104,6 +142,9 @@ const (
 	AlertTypeIntrusion AlertType = "intrusion_detected"
 	AlertTypeFace      AlertType = "unauthorized_face"
 	AlertTypeSystem    AlertType = "system_alert"
+	// AlertTypeIntel marque une correspondance avec une décision de
+	// renseignement distante ou un override local (voir core.IntelDetector).
+	AlertTypeIntel AlertType = "intel_match"
 )
 
 type AlertLevel string
@@ -0,0 +1,97 @@
+// internal/eventstream/server.go
+package eventstream
+
+import (
+	"log"
+	"net"
+
+	"surveillance-core/internal/core"
+	pb "surveillance-core/internal/eventstream/proto"
+
+	"google.golang.org/grpc"
+)
+
+// Server implémente pb.EventStreamServiceServer en s'appuyant sur
+// l'EventProcessor existant (Subscribe/ReplayEvents), pour offrir aux
+// consommateurs externes une alternative gRPC au flux NDJSON HTTP
+// (voir internal/api/handlers.go StreamEvents).
+type Server struct {
+	pb.UnimplementedEventStreamServiceServer
+	processor core.EventProcessor
+}
+
+// NewServer crée un serveur gRPC de flux d'événements adossé à processor.
+func NewServer(processor core.EventProcessor) *Server {
+	return &Server{processor: processor}
+}
+
+// SubscribeAlerts rejoue les alertes postérieures à req.SinceCursor depuis le
+// ring-buffer interne, puis bascule sur le flux live jusqu'à ce que le client
+// se déconnecte ou que l'abonné soit coupé pour lenteur.
+func (s *Server) SubscribeAlerts(req *pb.SubscribeRequest, stream pb.EventStreamService_SubscribeAlertsServer) error {
+	filter := core.EventFilter{
+		CameraID:  req.GetCameraId(),
+		AlertType: core.AlertType(req.GetAlertType()),
+		MinLevel:  core.AlertLevel(req.GetMinLevel()),
+	}
+
+	for _, event := range s.processor.ReplayEvents(req.GetSinceCursor()) {
+		if event.Kind != core.StreamEventAlert || event.Alert == nil {
+			continue
+		}
+		if err := stream.Send(toProtoAlert(event)); err != nil {
+			return err
+		}
+	}
+
+	events, errs, cancel := s.processor.Subscribe(filter)
+	defer cancel()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case err, ok := <-errs:
+			if ok && err != nil {
+				return err
+			}
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if event.Kind != core.StreamEventAlert || event.Alert == nil {
+				continue
+			}
+			if err := stream.Send(toProtoAlert(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func toProtoAlert(event core.StreamEvent) *pb.Alert {
+	return &pb.Alert{
+		Cursor:        event.Cursor,
+		Id:            event.Alert.ID,
+		CameraId:      event.Alert.CameraID,
+		Type:          string(event.Alert.Type),
+		Level:         string(event.Alert.Level),
+		Message:       event.Alert.Message,
+		TimestampUnix: event.Timestamp.Unix(),
+	}
+}
+
+// Serve démarre le serveur gRPC sur address et bloque jusqu'à erreur fatale.
+func Serve(address string, processor core.EventProcessor) error {
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	grpcServer := grpc.NewServer()
+	pb.RegisterEventStreamServiceServer(grpcServer, NewServer(processor))
+
+	log.Printf("Serveur gRPC de flux d'événements démarré sur %s", address)
+	return grpcServer.Serve(lis)
+}
@@ -0,0 +1,78 @@
+// internal/logging/entry.go
+package logging
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// Fields est l'équivalent local de logrus.Fields (même sous-jacent
+// map[string]interface{}), conservé pour que les appels existants passant
+// un map[string]interface{} littéral à WithFields restent valides tels quels.
+type Fields map[string]interface{}
+
+// Entry enveloppe un *zap.Logger et les champs accumulés par la chaîne
+// WithFields/WithField/WithError, sur le modèle de logrus.Entry qu'il
+// remplace : chaque caméra/alerte/détection doit apparaître en tant que
+// champ structuré plutôt qu'interpolée dans le message.
+type Entry struct {
+	logger *zap.Logger
+	fields []zap.Field
+}
+
+func (e *Entry) clone(extra ...zap.Field) *Entry {
+	fields := make([]zap.Field, len(e.fields), len(e.fields)+len(extra))
+	copy(fields, e.fields)
+	return &Entry{logger: e.logger, fields: append(fields, extra...)}
+}
+
+// WithFields retourne un Entry dérivé portant fields en plus des champs déjà
+// accumulés.
+func (e *Entry) WithFields(fields Fields) *Entry {
+	extra := make([]zap.Field, 0, len(fields))
+	for k, v := range fields {
+		extra = append(extra, zap.Any(k, v))
+	}
+	return e.clone(extra...)
+}
+
+// WithField retourne un Entry dérivé portant un champ de plus.
+func (e *Entry) WithField(key string, value interface{}) *Entry {
+	return e.clone(zap.Any(key, value))
+}
+
+// WithError retourne un Entry dérivé portant err sous la clé "error".
+func (e *Entry) WithError(err error) *Entry {
+	return e.clone(zap.Error(err))
+}
+
+func (e *Entry) Info(msg string)  { e.logger.Info(msg, e.fields...) }
+func (e *Entry) Warn(msg string)  { e.logger.Warn(msg, e.fields...) }
+func (e *Entry) Error(msg string) { e.logger.Error(msg, e.fields...) }
+
+func (e *Entry) Infof(format string, args ...interface{}) {
+	e.logger.Info(fmt.Sprintf(format, args...), e.fields...)
+}
+
+func (e *Entry) Errorf(format string, args ...interface{}) {
+	e.logger.Error(fmt.Sprintf(format, args...), e.fields...)
+}
+
+// CameraFields construit les champs structurés communs aux logs liés à une
+// caméra/un stream.
+func CameraFields(cameraID string) Fields {
+	return Fields{"camera_id": cameraID}
+}
+
+// AlertFields construit les champs structurés communs aux logs liés à une
+// alerte.
+func AlertFields(alertID string) Fields {
+	return Fields{"alert_id": alertID}
+}
+
+// DetectionFields construit les champs structurés communs aux logs liés à
+// une détection.
+func DetectionFields(detectionID string) Fields {
+	return Fields{"detection_id": detectionID}
+}
@@ -0,0 +1,125 @@
+// internal/logging/logging.go
+package logging
+
+import (
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Config reprend les champs de core.LoggingConfig sans en dépendre (core
+// importe déjà logging) : construit le *Entry partagé par vision, core et
+// les backends de notification, remplaçant le logger logrus historique.
+type Config struct {
+	Level      string // "debug", "info", "warn", "error"
+	Format     string // "json", "text"
+	Output     string // "stdout", "file", "both"
+	File       string
+	MaxSize    int
+	MaxAge     int
+	MaxBackups int
+	Compress   bool
+	// CameraSampleRate borne le nombre de lignes de log par caméra et par
+	// seconde via perCameraSampler ; zéro désactive l'échantillonnage.
+	CameraSampleRate int
+}
+
+// level est partagé par tous les Entry produits par Build, pour que Watch
+// puisse ajuster le niveau minimal à chaud sans reconstruire tout le logger.
+var level = zap.NewAtomicLevelAt(zap.InfoLevel)
+
+// Logger est le logger racine partagé, remplacé au démarrage par Build une
+// fois Config.Logging connu ; la valeur par défaut reste utilisable avant
+// ça (tests, appels depuis init()).
+var Logger = mustBuildDefault()
+
+func mustBuildDefault() *Entry {
+	entry, err := Build(Config{Level: "info", Format: "text", Output: "stdout"})
+	if err != nil {
+		// Le core par défaut (stdout, sans rotation) ne peut pas échouer.
+		panic(err)
+	}
+	return entry
+}
+
+// Build construit un *Entry (logger racine, sans champs additionnels)
+// depuis cfg. Les appelants l'enrichissent via WithFields/WithField/WithError
+// (camera_id, alert_id, detection_id, ...) plutôt que d'interpoler ces
+// valeurs dans le message.
+func Build(cfg Config) (*Entry, error) {
+	level.SetLevel(parseLevel(cfg.Level))
+
+	encoderCfg := zap.NewProductionEncoderConfig()
+	encoderCfg.TimeKey = "ts"
+	encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder
+
+	var encoder zapcore.Encoder
+	if cfg.Format == "json" {
+		encoder = zapcore.NewJSONEncoder(encoderCfg)
+	} else {
+		encoderCfg.EncodeLevel = zapcore.CapitalLevelEncoder
+		encoder = zapcore.NewConsoleEncoder(encoderCfg)
+	}
+
+	writer, err := writerFor(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var core zapcore.Core = zapcore.NewCore(encoder, writer, level)
+	if cfg.CameraSampleRate > 0 {
+		core = newPerCameraSampler(core, cfg.CameraSampleRate)
+	}
+
+	return &Entry{logger: zap.New(core)}, nil
+}
+
+func writerFor(cfg Config) (zapcore.WriteSyncer, error) {
+	switch cfg.Output {
+	case "file":
+		if cfg.File == "" {
+			return nil, fmt.Errorf("logging: output=file requires a file path")
+		}
+		return zapcore.AddSync(fileSink(cfg)), nil
+	case "both":
+		if cfg.File == "" {
+			return nil, fmt.Errorf("logging: output=both requires a file path")
+		}
+		return zapcore.NewMultiWriteSyncer(zapcore.AddSync(os.Stdout), zapcore.AddSync(fileSink(cfg))), nil
+	default:
+		return zapcore.AddSync(os.Stdout), nil
+	}
+}
+
+func fileSink(cfg Config) *lumberjack.Logger {
+	return &lumberjack.Logger{
+		Filename:   cfg.File,
+		MaxSize:    cfg.MaxSize,
+		MaxAge:     cfg.MaxAge,
+		MaxBackups: cfg.MaxBackups,
+		Compress:   cfg.Compress,
+	}
+}
+
+func parseLevel(lvl string) zapcore.Level {
+	switch lvl {
+	case "debug":
+		return zapcore.DebugLevel
+	case "warn":
+		return zapcore.WarnLevel
+	case "error":
+		return zapcore.ErrorLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
+
+// SetLevel ajuste à chaud le niveau minimal de Logger (et de tout Entry déjà
+// dérivé, le niveau étant partagé) : branché sur core.ConfigManager.Watch
+// pour que Logging.Level se recharge sans reconstruire tout le logger.
+func SetLevel(lvl string) {
+	level.SetLevel(parseLevel(lvl))
+}
@@ -0,0 +1,76 @@
+// internal/logging/sampler.go
+package logging
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// perCameraSampler limite chaque camera_id à maxPerSecond lignes par fenêtre
+// fixe d'une seconde (ouverte par la première ligne après l'expiration de la
+// précédente), plutôt que le sampler intégré de zap qui clé sur (niveau,
+// message) et plafonnerait toutes les caméras d'un coup dès que l'une
+// d'elles spamme un message identique.
+type perCameraSampler struct {
+	zapcore.Core
+	maxPerSecond int
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	windowStart time.Time
+	count       int
+}
+
+func newPerCameraSampler(core zapcore.Core, maxPerSecond int) *perCameraSampler {
+	return &perCameraSampler{
+		Core:         core,
+		maxPerSecond: maxPerSecond,
+		buckets:      make(map[string]*bucket),
+	}
+}
+
+func (s *perCameraSampler) With(fields []zapcore.Field) zapcore.Core {
+	return &perCameraSampler{Core: s.Core.With(fields), maxPerSecond: s.maxPerSecond, buckets: s.buckets}
+}
+
+func (s *perCameraSampler) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if s.Core.Enabled(entry.Level) {
+		return checked.AddCore(entry, s)
+	}
+	return checked
+}
+
+func (s *perCameraSampler) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	if cameraID := cameraIDFromFields(fields); cameraID != "" && !s.allow(cameraID) {
+		return nil
+	}
+	return s.Core.Write(entry, fields)
+}
+
+func (s *perCameraSampler) allow(cameraID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	b, ok := s.buckets[cameraID]
+	if !ok || now.Sub(b.windowStart) >= time.Second {
+		b = &bucket{windowStart: now}
+		s.buckets[cameraID] = b
+	}
+	b.count++
+	return b.count <= s.maxPerSecond
+}
+
+func cameraIDFromFields(fields []zapcore.Field) string {
+	for _, f := range fields {
+		if f.Key == "camera_id" && f.Type == zapcore.StringType {
+			return f.String
+		}
+	}
+	return ""
+}
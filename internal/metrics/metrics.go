@@ -0,0 +1,219 @@
+// internal/metrics/metrics.go
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// FramesReceivedTotal compte les frames effectivement livrées au channel de
+// sortie d'un stream, par caméra.
+var FramesReceivedTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "frames_received_total",
+		Help: "Nombre total de frames reçues et livrées par caméra.",
+	},
+	[]string{"camera_id"},
+)
+
+// FramesDroppedTotal compte les frames perdues faute de place dans le
+// channel de sortie (branche "canal plein" de generateFrames).
+var FramesDroppedTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "frames_dropped_total",
+		Help: "Nombre total de frames abandonnées car le channel de sortie était plein.",
+	},
+	[]string{"camera_id"},
+)
+
+// StreamStatus reflète l'état courant d'un stream par caméra (0=stopped,
+// 1=starting, 2=active, 3=error), pour graphe d'état dans Grafana.
+var StreamStatus = promauto.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "stream_status",
+		Help: "État courant du stream par caméra (0=stopped, 1=starting, 2=active, 3=error).",
+	},
+	[]string{"camera_id"},
+)
+
+// DetectionsTotal compte les détections traitées par EventProcessor, par
+// caméra et par type.
+var DetectionsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "detections_total",
+		Help: "Nombre total de détections traitées, par caméra et par type.",
+	},
+	[]string{"camera_id", "type"},
+)
+
+// AlertsTotal compte les alertes enregistrées par AlertManager, par niveau
+// et par type.
+var AlertsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "alerts_total",
+		Help: "Nombre total d'alertes enregistrées, par niveau et par type.",
+	},
+	[]string{"level", "type"},
+)
+
+// AlertsPending reflète le nombre d'alertes non acquittées, mis à jour à
+// chaque lecture des statistiques d'AlertManager.
+var AlertsPending = promauto.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "alerts_pending",
+		Help: "Nombre d'alertes actuellement non acquittées.",
+	},
+)
+
+// DetectionProcessingDuration mesure la latence d'EventProcessor.ProcessDetectionCtx,
+// de l'entrée dans le pipeline jusqu'au dispatch des alertes produites.
+var DetectionProcessingDuration = promauto.NewHistogram(
+	prometheus.HistogramOpts{
+		Name:    "detection_processing_duration_seconds",
+		Help:    "Durée de traitement d'une détection par l'EventProcessor, du début à la fin du pipeline.",
+		Buckets: prometheus.DefBuckets,
+	},
+)
+
+// DetectorsRegistered reflète le nombre de détecteurs actuellement
+// enregistrés auprès de l'EventProcessor.
+var DetectorsRegistered = promauto.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "detectors_registered",
+		Help: "Nombre de détecteurs actuellement enregistrés auprès de l'EventProcessor.",
+	},
+)
+
+// NotifyDuration mesure la latence d'envoi d'une alerte à un backend de
+// notification, par backend.
+var NotifyDuration = promauto.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "notify_duration_seconds",
+		Help:    "Durée d'envoi d'une alerte ou d'un rapport de session à un backend de notification.",
+		Buckets: prometheus.DefBuckets,
+	},
+	[]string{"notifier"},
+)
+
+// NotifyRetriesTotal compte les tentatives de renvoi (au-delà de la
+// première) vers un backend de notification, par backend.
+var NotifyRetriesTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "notify_retries_total",
+		Help: "Nombre total de tentatives de renvoi (hors premier essai) vers un backend de notification.",
+	},
+	[]string{"notifier"},
+)
+
+// NotifierBreakerState reflète l'état du circuit breaker d'un backend de
+// notification (0=closed, 1=open, 2=half-open).
+var NotifierBreakerState = promauto.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "notifier_breaker_state",
+		Help: "État du circuit breaker par backend de notification (0=closed, 1=open, 2=half-open).",
+	},
+	[]string{"notifier"},
+)
+
+// VisionHealthCheckDuration mesure la latence des appels grpc.health.v1
+// Check vers le service vision, tentatives de retry incluses.
+var VisionHealthCheckDuration = promauto.NewHistogram(
+	prometheus.HistogramOpts{
+		Name:    "vision_health_check_duration_seconds",
+		Help:    "Durée totale d'un cycle de healthcheck gRPC vers le service vision (retries inclus).",
+		Buckets: prometheus.DefBuckets,
+	},
+)
+
+// VisionHealthCheckRetriesTotal compte les tentatives de Check (au-delà de
+// la première) vers le service vision.
+var VisionHealthCheckRetriesTotal = promauto.NewCounter(
+	prometheus.CounterOpts{
+		Name: "vision_health_check_retries_total",
+		Help: "Nombre total de tentatives de retry des healthchecks gRPC vers le service vision.",
+	},
+)
+
+// VisionHealthStatus reflète le dernier statut de santé rapporté par le
+// service vision (0=unhealthy, 1=healthy).
+var VisionHealthStatus = promauto.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "vision_health_status",
+		Help: "Dernier statut de santé du service vision (0=unhealthy, 1=healthy).",
+	},
+)
+
+// EventQueueDepth reflète, après chaque traitement, le nombre de détections
+// encore en attente dans la file d'un shard d'EventProcessor.
+var EventQueueDepth = promauto.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "event_queue_depth",
+		Help: "Nombre de détections en attente dans la file d'un shard d'EventProcessor.",
+	},
+	[]string{"shard"},
+)
+
+// EventQueueDroppedTotal compte les détections rejetées parce que la file de
+// leur shard était pleine, par DropPolicy appliquée.
+var EventQueueDroppedTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "event_queue_dropped_total",
+		Help: "Nombre total de détections rejetées par saturation de la file d'un shard, par DropPolicy.",
+	},
+	[]string{"policy"},
+)
+
+// AlertDispatchDroppedTotal compte les alertes abandonnées parce que la file
+// de dispatch d'EventProcessor (alertDispatch) était pleine, typiquement
+// parce que tous les notifiers sont lents ou en panne simultanément (voir
+// core.eventProcessor.dispatchAlert).
+var AlertDispatchDroppedTotal = promauto.NewCounter(
+	prometheus.CounterOpts{
+		Name: "alert_dispatch_dropped_total",
+		Help: "Nombre total d'alertes abandonnées par saturation de la file de dispatch d'EventProcessor.",
+	},
+)
+
+// AlertAddDuration mesure la latence d'AlertManager.AddAlert (écriture dans
+// l'AlertStore sous-jacent : mémoire, SQLite ou Postgres).
+var AlertAddDuration = promauto.NewHistogram(
+	prometheus.HistogramOpts{
+		Name:    "alert_add_duration_seconds",
+		Help:    "Durée d'insertion d'une alerte dans l'AlertStore.",
+		Buckets: prometheus.DefBuckets,
+	},
+)
+
+// AlertQueryDuration mesure la latence des lectures d'alertes (GetAlerts,
+// GetAlertsFiltered, GetAlertsByCamera).
+var AlertQueryDuration = promauto.NewHistogram(
+	prometheus.HistogramOpts{
+		Name:    "alert_query_duration_seconds",
+		Help:    "Durée des requêtes de lecture d'alertes sur l'AlertStore.",
+		Buckets: prometheus.DefBuckets,
+	},
+)
+
+// Handler retourne le handler HTTP standard exposant les métriques au
+// format texte Prometheus, à monter sur /metrics.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// StreamStatusValue convertit un statut de stream textuel en valeur
+// numérique pour la gauge StreamStatus.
+func StreamStatusValue(status string) float64 {
+	switch status {
+	case "starting":
+		return 1
+	case "active":
+		return 2
+	case "error":
+		return 3
+	default: // "stopped"
+		return 0
+	}
+}
@@ -0,0 +1,79 @@
+// internal/notify/factory.go
+package notify
+
+import (
+	"fmt"
+	"strings"
+
+	"surveillance-core/internal/core"
+	wsHub "surveillance-core/internal/websocket"
+)
+
+// NewFromChannel construit le Notifier correspondant à channel.Type, en
+// lisant ses options dans channel.Config. hub n'est requis que pour le
+// type "websocket". Retourne une erreur si le type est inconnu ou si un
+// champ requis manque dans Config.
+func NewFromChannel(channel core.NotificationChannel, hub *wsHub.Hub, alertTemplatePath, reportTemplatePath string) (core.Notifier, error) {
+	switch strings.ToLower(channel.Type) {
+	case "websocket":
+		if hub == nil {
+			return nil, fmt.Errorf("canal de notification %q: hub WebSocket non fourni", channel.Name)
+		}
+		return NewWebSocketNotifier(hub), nil
+
+	case "webhook":
+		url := channel.Config["url"]
+		if url == "" {
+			return nil, fmt.Errorf("canal de notification %q: champ 'url' requis", channel.Name)
+		}
+		return NewWebhookNotifier(channel.Name, url, alertTemplatePath, reportTemplatePath, channel.TLS)
+
+	case "slack", "discord":
+		url := channel.Config["webhook_url"]
+		if url == "" {
+			return nil, fmt.Errorf("canal de notification %q: champ 'webhook_url' requis", channel.Name)
+		}
+		return NewSlackNotifier(channel.Name, url, alertTemplatePath, reportTemplatePath)
+
+	case "email", "smtp":
+		addr := channel.Config["smtp_addr"]
+		from := channel.Config["from"]
+		to := channel.Config["to"]
+		if addr == "" || from == "" || to == "" {
+			return nil, fmt.Errorf("canal de notification %q: champs 'smtp_addr', 'from' et 'to' requis", channel.Name)
+		}
+		return NewSMTPNotifier(
+			channel.Name,
+			addr,
+			channel.Config["username"],
+			channel.Config["password"],
+			from,
+			strings.Split(to, ","),
+			alertTemplatePath,
+			reportTemplatePath,
+			channel.TLS,
+		)
+
+	case "sms", "twilio":
+		apiURL := channel.Config["api_url"]
+		from := channel.Config["from"]
+		to := channel.Config["to"]
+		if apiURL == "" || from == "" || to == "" {
+			return nil, fmt.Errorf("canal de notification %q: champs 'api_url', 'from' et 'to' requis", channel.Name)
+		}
+		return NewSMSNotifier(
+			channel.Name,
+			apiURL,
+			channel.Config["account_sid"],
+			channel.Config["auth_token"],
+			from,
+			strings.Split(to, ","),
+			alertTemplatePath,
+			reportTemplatePath,
+			channel.TLS,
+		)
+
+	default:
+		return nil, fmt.Errorf("canal de notification %q: type inconnu %q", channel.Name, channel.Type)
+	}
+}
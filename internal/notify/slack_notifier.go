@@ -0,0 +1,89 @@
+// internal/notify/slack_notifier.go
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"text/template"
+	"time"
+
+	"surveillance-core/internal/core"
+)
+
+const defaultSlackAlertTemplate = `:rotating_light: *{{.Level}}* sur {{.CameraID}}: {{.Message}}`
+const defaultSlackReportTemplate = `:bar_chart: Rapport de session: {{.TotalAlerts}} alertes en {{.Duration}}`
+
+type slackPayload struct {
+	Text string `json:"text"`
+}
+
+// SlackNotifier poste un message texte vers un webhook entrant Slack ou
+// Discord (les deux acceptent le même payload {"text": "..."}), au format
+// rendu depuis un template configurable.
+type SlackNotifier struct {
+	name           string
+	webhookURL     string
+	client         *http.Client
+	alertTemplate  *template.Template
+	reportTemplate *template.Template
+}
+
+// NewSlackNotifier crée un notifier nommé name postant vers webhookURL.
+func NewSlackNotifier(name, webhookURL, alertTemplatePath, reportTemplatePath string) (*SlackNotifier, error) {
+	alertTmpl, err := core.LoadTemplate(alertTemplatePath, defaultSlackAlertTemplate)
+	if err != nil {
+		return nil, err
+	}
+	reportTmpl, err := core.LoadTemplate(reportTemplatePath, defaultSlackReportTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SlackNotifier{
+		name:           name,
+		webhookURL:     webhookURL,
+		client:         &http.Client{Timeout: 10 * time.Second},
+		alertTemplate:  alertTmpl,
+		reportTemplate: reportTmpl,
+	}, nil
+}
+
+func (n *SlackNotifier) Name() string {
+	return n.name
+}
+
+func (n *SlackNotifier) Send(alert core.Alert) error {
+	text, err := core.RenderTemplate(n.alertTemplate, alert)
+	if err != nil {
+		return fmt.Errorf("rendu du template d'alerte: %w", err)
+	}
+	return n.post(text)
+}
+
+func (n *SlackNotifier) SendReport(report core.SessionReport) error {
+	text, err := core.RenderTemplate(n.reportTemplate, report)
+	if err != nil {
+		return fmt.Errorf("rendu du template de rapport: %w", err)
+	}
+	return n.post(text)
+}
+
+func (n *SlackNotifier) post(text string) error {
+	body, err := json.Marshal(slackPayload{Text: text})
+	if err != nil {
+		return err
+	}
+
+	resp, err := n.client.Post(n.webhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("appel webhook %s: %w", n.webhookURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("webhook %s a répondu %s", n.webhookURL, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+// internal/notify/sms_notifier.go
+package notify
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"surveillance-core/internal/core"
+)
+
+const defaultSMSAlertTemplate = `[{{.Level}}] {{.CameraID}}: {{.Message}}`
+const defaultSMSReportTemplate = `Rapport de session: {{.TotalAlerts}} alertes en {{.Duration}}`
+
+// SMSNotifier envoie les alertes et rapports de session par SMS via l'API
+// REST d'un fournisseur de type Twilio (Messages resource, auth HTTP basic
+// par Account SID / Auth Token), sur le même modèle post-form que les
+// webhooks Slack/Discord plutôt qu'une dépendance SDK dédiée.
+type SMSNotifier struct {
+	name           string
+	apiURL         string
+	accountSID     string
+	authToken      string
+	from           string
+	to             []string
+	client         *http.Client
+	alertTemplate  *template.Template
+	reportTemplate *template.Template
+}
+
+// NewSMSNotifier crée un notifier nommé name, postant vers apiURL (l'URL
+// complète de la resource Messages du fournisseur, Account SID déjà inclus
+// pour Twilio). tlsCfg configure le transport, pour les passerelles SMS
+// internes en mTLS.
+func NewSMSNotifier(name, apiURL, accountSID, authToken, from string, to []string, alertTemplatePath, reportTemplatePath string, tlsCfg core.TLSCfg) (*SMSNotifier, error) {
+	alertTmpl, err := core.LoadTemplate(alertTemplatePath, defaultSMSAlertTemplate)
+	if err != nil {
+		return nil, err
+	}
+	reportTmpl, err := core.LoadTemplate(reportTemplatePath, defaultSMSReportTemplate)
+	if err != nil {
+		return nil, err
+	}
+	client, err := newHTTPClient(tlsCfg, 10*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("canal SMS %q: %w", name, err)
+	}
+
+	return &SMSNotifier{
+		name:           name,
+		apiURL:         apiURL,
+		accountSID:     accountSID,
+		authToken:      authToken,
+		from:           from,
+		to:             to,
+		client:         client,
+		alertTemplate:  alertTmpl,
+		reportTemplate: reportTmpl,
+	}, nil
+}
+
+func (n *SMSNotifier) Name() string {
+	return n.name
+}
+
+func (n *SMSNotifier) Send(alert core.Alert) error {
+	body, err := core.RenderTemplate(n.alertTemplate, alert)
+	if err != nil {
+		return fmt.Errorf("rendu du template d'alerte: %w", err)
+	}
+	return n.sendAll(body)
+}
+
+func (n *SMSNotifier) SendReport(report core.SessionReport) error {
+	body, err := core.RenderTemplate(n.reportTemplate, report)
+	if err != nil {
+		return fmt.Errorf("rendu du template de rapport: %w", err)
+	}
+	return n.sendAll(body)
+}
+
+// sendAll poste un message séparé par destinataire, pour qu'un numéro
+// invalide ne fasse pas échouer l'envoi aux autres.
+func (n *SMSNotifier) sendAll(body string) error {
+	var errs []string
+	for _, to := range n.to {
+		if err := n.sendOne(to, body); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("envoi SMS: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (n *SMSNotifier) sendOne(to, body string) error {
+	form := url.Values{
+		"From": {n.from},
+		"To":   {to},
+		"Body": {body},
+	}
+
+	req, err := http.NewRequest(http.MethodPost, n.apiURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("construction de la requête SMS vers %s: %w", to, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(n.accountSID, n.authToken)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("appel API SMS vers %s: %w", to, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("API SMS a répondu %s pour %s", resp.Status, to)
+	}
+	return nil
+}
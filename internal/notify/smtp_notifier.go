@@ -0,0 +1,174 @@
+// internal/notify/smtp_notifier.go
+package notify
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"surveillance-core/internal/core"
+)
+
+const defaultEmailAlertTemplate = "Subject: [{{noCRLF .Level}}] Alerte caméra {{noCRLF .CameraID}}\r\n\r\n{{noCRLF .Message}}\r\n"
+const defaultEmailReportTemplate = "Subject: Rapport de session surveillance\r\n\r\n{{.TotalAlerts}} alertes en {{.Duration}}.\r\n"
+
+// smtpTemplateFuncs expose un helper "noCRLF" aux templates d'alerte/rapport
+// e-mail : un CameraID/Message contenant \r\n pourrait sinon forger des
+// en-têtes ou du contenu SMTP supplémentaires une fois interpolé tel quel
+// dans le message brut (même classe de bug que webhook_notifier.go, corrigée
+// là par le helper "json").
+var smtpTemplateFuncs = template.FuncMap{
+	"noCRLF": func(v interface{}) string {
+		s := fmt.Sprint(v)
+		s = strings.ReplaceAll(s, "\r", "")
+		s = strings.ReplaceAll(s, "\n", "")
+		return s
+	},
+}
+
+// loadSMTPTemplate est l'équivalent de core.LoadTemplate, avec en plus le
+// FuncMap ci-dessus enregistré sur le template.
+func loadSMTPTemplate(path, defaultText string) (*template.Template, error) {
+	if path == "" {
+		return template.New("default").Funcs(smtpTemplateFuncs).Parse(defaultText)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("lecture du template %s: %w", path, err)
+	}
+	return template.New(filepath.Base(path)).Funcs(smtpTemplateFuncs).Parse(string(data))
+}
+
+// SMTPNotifier envoie les alertes et rapports de session par e-mail via un
+// relais SMTP simple (net/smtp, sans authentification avancée type OAuth).
+type SMTPNotifier struct {
+	name           string
+	addr           string
+	host           string
+	auth           smtp.Auth
+	from           string
+	to             []string
+	tls            core.TLSCfg
+	alertTemplate  *template.Template
+	reportTemplate *template.Template
+}
+
+// NewSMTPNotifier crée un notifier nommé name, relayant via addr
+// ("host:port"). username peut être vide pour un relais sans
+// authentification. tlsCfg, si Enabled, force une session STARTTLS
+// explicite (certificat client / CA personnalisée) plutôt que le
+// smtp.SendMail par défaut, pour les relais internes en mTLS.
+func NewSMTPNotifier(name, addr, username, password, from string, to []string, alertTemplatePath, reportTemplatePath string, tlsCfg core.TLSCfg) (*SMTPNotifier, error) {
+	alertTmpl, err := loadSMTPTemplate(alertTemplatePath, defaultEmailAlertTemplate)
+	if err != nil {
+		return nil, err
+	}
+	reportTmpl, err := loadSMTPTemplate(reportTemplatePath, defaultEmailReportTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("adresse SMTP invalide %q: %w", addr, err)
+	}
+
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTPNotifier{
+		name:           name,
+		addr:           addr,
+		host:           host,
+		auth:           auth,
+		from:           from,
+		to:             to,
+		tls:            tlsCfg,
+		alertTemplate:  alertTmpl,
+		reportTemplate: reportTmpl,
+	}, nil
+}
+
+func (n *SMTPNotifier) Name() string {
+	return n.name
+}
+
+func (n *SMTPNotifier) Send(alert core.Alert) error {
+	body, err := core.RenderTemplate(n.alertTemplate, alert)
+	if err != nil {
+		return fmt.Errorf("rendu du template d'alerte: %w", err)
+	}
+	return n.sendMail(body)
+}
+
+func (n *SMTPNotifier) SendReport(report core.SessionReport) error {
+	body, err := core.RenderTemplate(n.reportTemplate, report)
+	if err != nil {
+		return fmt.Errorf("rendu du template de rapport: %w", err)
+	}
+	return n.sendMail(body)
+}
+
+func (n *SMTPNotifier) sendMail(body string) error {
+	if !n.tls.Enabled {
+		if err := smtp.SendMail(n.addr, n.auth, n.from, n.to, []byte(body)); err != nil {
+			return fmt.Errorf("envoi SMTP via %s: %w", n.addr, err)
+		}
+		return nil
+	}
+	return n.sendMailTLS(body)
+}
+
+// sendMailTLS pilote une session SMTP à la main (contrairement à
+// smtp.SendMail) pour pouvoir passer un *tls.Config personnalisé à
+// STARTTLS, seule façon de présenter un certificat client ou une CA interne.
+func (n *SMTPNotifier) sendMailTLS(body string) error {
+	tlsConfig, err := buildTLSConfig(n.tls)
+	if err != nil {
+		return fmt.Errorf("canal SMTP %q: %w", n.name, err)
+	}
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = n.host
+	}
+
+	client, err := smtp.Dial(n.addr)
+	if err != nil {
+		return fmt.Errorf("connexion SMTP %s: %w", n.addr, err)
+	}
+	defer client.Close()
+
+	if err := client.StartTLS(tlsConfig); err != nil {
+		return fmt.Errorf("STARTTLS vers %s: %w", n.addr, err)
+	}
+	if n.auth != nil {
+		if err := client.Auth(n.auth); err != nil {
+			return fmt.Errorf("authentification SMTP vers %s: %w", n.addr, err)
+		}
+	}
+	if err := client.Mail(n.from); err != nil {
+		return fmt.Errorf("MAIL FROM vers %s: %w", n.addr, err)
+	}
+	for _, to := range n.to {
+		if err := client.Rcpt(to); err != nil {
+			return fmt.Errorf("RCPT TO %s vers %s: %w", to, n.addr, err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("DATA vers %s: %w", n.addr, err)
+	}
+	if _, err := w.Write([]byte(body)); err != nil {
+		return fmt.Errorf("écriture du message vers %s: %w", n.addr, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("clôture du message vers %s: %w", n.addr, err)
+	}
+	return client.Quit()
+}
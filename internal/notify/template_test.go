@@ -0,0 +1,62 @@
+// internal/notify/template_test.go
+package notify
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"surveillance-core/internal/core"
+)
+
+func maliciousAlert() core.Alert {
+	return core.Alert{
+		CameraID: "cam1\r\nBcc: attacker@evil.example",
+		Level:    core.AlertLevelCritical,
+		Message:  "intrusion détectée\"\r\n",
+	}
+}
+
+func TestSMTPAlertTemplate_StripsCRLF(t *testing.T) {
+	tmpl, err := loadSMTPTemplate("", defaultEmailAlertTemplate)
+	if err != nil {
+		t.Fatalf("loadSMTPTemplate: %v", err)
+	}
+
+	body, err := core.RenderTemplate(tmpl, maliciousAlert())
+	if err != nil {
+		t.Fatalf("RenderTemplate: %v", err)
+	}
+
+	headerEnd := strings.Index(body, "\r\n\r\n")
+	if headerEnd == -1 {
+		t.Fatalf("expected exactly one header/body separator, got body %q", body)
+	}
+	header := body[:headerEnd]
+	if strings.Contains(header, "Bcc:") {
+		t.Errorf("expected the injected Bcc header to be stripped, got header %q", header)
+	}
+	if strings.Count(header, "\r\n") != 0 {
+		t.Errorf("expected no embedded CRLF inside the Subject header, got %q", header)
+	}
+}
+
+func TestWebhookAlertTemplate_EscapesInterpolatedFields(t *testing.T) {
+	tmpl, err := loadWebhookTemplate("", defaultWebhookAlertTemplate)
+	if err != nil {
+		t.Fatalf("loadWebhookTemplate: %v", err)
+	}
+
+	body, err := core.RenderTemplate(tmpl, maliciousAlert())
+	if err != nil {
+		t.Fatalf("RenderTemplate: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &decoded); err != nil {
+		t.Fatalf("expected valid JSON, got %q: %v", body, err)
+	}
+	if decoded["camera_id"] != maliciousAlert().CameraID {
+		t.Errorf("expected camera_id to round-trip unmodified, got %v", decoded["camera_id"])
+	}
+}
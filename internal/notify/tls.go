@@ -0,0 +1,63 @@
+// internal/notify/tls.go
+package notify
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"surveillance-core/internal/core"
+)
+
+// newHTTPClient construit un *http.Client pour un notifier HTTP (webhook,
+// SMS), avec un transport TLS/mTLS personnalisé si cfg.Enabled, sinon le
+// transport par défaut de net/http.
+func newHTTPClient(cfg core.TLSCfg, timeout time.Duration) (*http.Client, error) {
+	if !cfg.Enabled {
+		return &http.Client{Timeout: timeout}, nil
+	}
+
+	tlsConfig, err := buildTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}, nil
+}
+
+// buildTLSConfig traduit un core.TLSCfg en *tls.Config, en chargeant le
+// certificat client (mTLS) et la CA personnalisée quand ils sont renseignés.
+func buildTLSConfig(cfg core.TLSCfg) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         cfg.ServerName,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CertFile != "" && cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("chargement du certificat client %s: %w", cfg.CertFile, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("lecture de la CA %s: %w", cfg.CAFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA %s: aucun certificat PEM valide", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
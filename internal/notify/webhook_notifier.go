@@ -0,0 +1,117 @@
+// internal/notify/webhook_notifier.go
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"text/template"
+	"time"
+
+	"surveillance-core/internal/core"
+)
+
+const defaultWebhookAlertTemplate = `{"camera_id":{{json .CameraID}},"type":{{json .Type}},"level":{{json .Level}},"message":{{json .Message}}}`
+const defaultWebhookReportTemplate = `{"total_alerts":{{.TotalAlerts}},"duration":"{{.Duration}}"}`
+
+// webhookTemplateFuncs expose un helper "json" aux templates d'alerte/rapport
+// webhook : il marshalle la valeur plutôt que de l'interpoler telle quelle
+// dans le gabarit JSON, pour qu'un CameraID/Message contenant des guillemets
+// ou des retours à la ligne ne corrompe pas le corps envoyé au endpoint.
+var webhookTemplateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	},
+}
+
+// loadWebhookTemplate est l'équivalent de core.LoadTemplate, avec en plus le
+// FuncMap ci-dessus enregistré sur le template.
+func loadWebhookTemplate(path, defaultText string) (*template.Template, error) {
+	if path == "" {
+		return template.New("default").Funcs(webhookTemplateFuncs).Parse(defaultText)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("lecture du template %s: %w", path, err)
+	}
+	return template.New(filepath.Base(path)).Funcs(webhookTemplateFuncs).Parse(string(data))
+}
+
+// WebhookNotifier poste le rendu d'un template JSON vers un endpoint HTTP
+// générique, pour les intégrations qui n'ont pas de format propriétaire
+// (contrairement à Slack/Discord, voir slack_notifier.go).
+type WebhookNotifier struct {
+	name           string
+	url            string
+	client         *http.Client
+	alertTemplate  *template.Template
+	reportTemplate *template.Template
+}
+
+// NewWebhookNotifier crée un notifier webhook nommé name, postant vers url.
+// alertTemplatePath/reportTemplatePath pointent vers des templates sur
+// disque ; vides, le gabarit JSON par défaut est utilisé. tlsCfg configure
+// le transport, pour poster vers un endpoint interne derrière TLS/mTLS.
+func NewWebhookNotifier(name, url, alertTemplatePath, reportTemplatePath string, tlsCfg core.TLSCfg) (*WebhookNotifier, error) {
+	alertTmpl, err := loadWebhookTemplate(alertTemplatePath, defaultWebhookAlertTemplate)
+	if err != nil {
+		return nil, err
+	}
+	reportTmpl, err := loadWebhookTemplate(reportTemplatePath, defaultWebhookReportTemplate)
+	if err != nil {
+		return nil, err
+	}
+	client, err := newHTTPClient(tlsCfg, 10*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("canal webhook %q: %w", name, err)
+	}
+
+	return &WebhookNotifier{
+		name:           name,
+		url:            url,
+		client:         client,
+		alertTemplate:  alertTmpl,
+		reportTemplate: reportTmpl,
+	}, nil
+}
+
+func (n *WebhookNotifier) Name() string {
+	return n.name
+}
+
+func (n *WebhookNotifier) Send(alert core.Alert) error {
+	body, err := core.RenderTemplate(n.alertTemplate, alert)
+	if err != nil {
+		return fmt.Errorf("rendu du template d'alerte: %w", err)
+	}
+	return n.post(body)
+}
+
+func (n *WebhookNotifier) SendReport(report core.SessionReport) error {
+	body, err := core.RenderTemplate(n.reportTemplate, report)
+	if err != nil {
+		return fmt.Errorf("rendu du template de rapport: %w", err)
+	}
+	return n.post(body)
+}
+
+func (n *WebhookNotifier) post(body string) error {
+	resp, err := n.client.Post(n.url, "application/json", bytes.NewBufferString(body))
+	if err != nil {
+		return fmt.Errorf("appel webhook %s: %w", n.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("webhook %s a répondu %s", n.url, resp.Status)
+	}
+	return nil
+}
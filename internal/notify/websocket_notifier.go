@@ -0,0 +1,39 @@
+// internal/notify/websocket_notifier.go
+package notify
+
+import (
+	"surveillance-core/internal/core"
+	wsHub "surveillance-core/internal/websocket"
+)
+
+// WebSocketNotifier diffuse les alertes et rapports de session aux clients
+// WebSocket connectés, en remplacement du hub.Broadcast câblé en dur
+// précédemment dans cmd/server/main.go.
+type WebSocketNotifier struct {
+	hub *wsHub.Hub
+}
+
+// NewWebSocketNotifier crée un notifier qui diffuse sur hub.
+func NewWebSocketNotifier(hub *wsHub.Hub) *WebSocketNotifier {
+	return &WebSocketNotifier{hub: hub}
+}
+
+func (n *WebSocketNotifier) Name() string {
+	return "websocket"
+}
+
+// Send publie alert sur ses topics caméra et sévérité ("camera:cam_001",
+// "alerts:critical") plutôt qu'en broadcast global, pour qu'un client
+// n'affichant qu'un sous-ensemble de caméras/sévérités ne reçoive que ce
+// qui le concerne (voir Hub.BroadcastTo).
+func (n *WebSocketNotifier) Send(alert core.Alert) error {
+	message := wsHub.Message{Type: "alert", Data: alert, Timestamp: alert.Timestamp}
+	n.hub.BroadcastTo("camera:"+alert.CameraID, message)
+	n.hub.BroadcastTo("alerts:"+string(alert.Level), message)
+	return nil
+}
+
+func (n *WebSocketNotifier) SendReport(report core.SessionReport) error {
+	n.hub.Broadcast(wsHub.Message{Type: "session_report", Data: report, Timestamp: report.End})
+	return nil
+}
@@ -0,0 +1,60 @@
+// internal/telemetry/telemetry.go
+//
+// telemetry installe le TracerProvider OpenTelemetry global utilisé par
+// core.EventProcessor, core.NotifierRegistry et vision.grpcClient pour
+// propager un span depuis l'ingestion d'une frame jusqu'au dispatch d'alerte
+// (voir core.ObservabilityConfig). Un OTLPEndpoint vide installe un
+// TracerProvider no-op : les spans créés par otel.Tracer(...) ne coûtent
+// alors quasiment rien et ne partent nulle part.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"surveillance-core/internal/core"
+)
+
+// Shutdown arrête proprement l'exporteur de traces, à appeler pendant
+// l'extinction du serveur dans le même délai que les autres composants.
+type Shutdown func(ctx context.Context) error
+
+// Init construit et installe (via otel.SetTracerProvider) le TracerProvider
+// décrit par cfg. Avec OTLPEndpoint vide, installe un TracerProvider no-op
+// et renvoie un Shutdown qui ne fait rien.
+func Init(ctx context.Context, cfg core.ObservabilityConfig) (Shutdown, error) {
+	if cfg.OTLPEndpoint == "" {
+		otel.SetTracerProvider(trace.NewNoopTracerProvider())
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint), otlptracegrpc.WithInsecure())
+	if err != nil {
+		return nil, fmt.Errorf("telemetry: création de l'exportateur OTLP: %w", err)
+	}
+
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = "surveillance-core"
+	}
+	res, err := resource.New(ctx, resource.WithAttributes(semconv.ServiceNameKey.String(serviceName)))
+	if err != nil {
+		return nil, fmt.Errorf("telemetry: construction de la resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SamplingRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
@@ -0,0 +1,325 @@
+package vision
+
+import (
+	"bytes"
+	"image/color"
+	"image/jpeg"
+	"sync"
+
+	"surveillance-core/internal/core"
+
+	"github.com/google/uuid"
+)
+
+// Detector est un plugin de détection optionnel (ONNX/YOLO ou équivalent)
+// exécuté en complément de la détection de mouvement classique pour
+// distinguer personnes et véhicules.
+type Detector interface {
+	Detect(frame core.Frame) ([]core.Detection, error)
+}
+
+// AnalyzerConfig configure le pipeline de détection.
+type AnalyzerConfig struct {
+	// Sensitivity est le seuil de différence de niveau de gris (0-255)
+	// au-delà duquel un pixel est considéré en mouvement.
+	Sensitivity int
+	// MinContourArea est la surface minimale (en pixels) d'un blob de
+	// mouvement pour générer une détection.
+	MinContourArea int
+	// BackgroundLearningRate contrôle la vitesse d'adaptation du modèle de
+	// fond (MOG2 simplifié), entre 0 (jamais) et 1 (instantané).
+	BackgroundLearningRate float64
+	// ObjectDetector est un plugin optionnel de classification
+	// personne/véhicule. Si nil, seules des détections de type Motion sont
+	// produites.
+	ObjectDetector Detector
+}
+
+// DefaultAnalyzerConfig retourne une configuration par défaut raisonnable.
+func DefaultAnalyzerConfig() AnalyzerConfig {
+	return AnalyzerConfig{
+		Sensitivity:            25,
+		MinContourArea:         500,
+		BackgroundLearningRate: 0.05,
+	}
+}
+
+// backgroundModel conserve un modèle de fond par caméra pour la
+// soustraction d'arrière-plan (moyenne mobile exponentielle par pixel,
+// approximation simplifiée de MOG2).
+type backgroundModel struct {
+	gray   []float64
+	width  int
+	height int
+	primed bool
+}
+
+// FrameAnalyzer consomme des core.Frame et produit des core.Detection par
+// différenciation d'image, soustraction de fond et masquage par zone, avec
+// classification optionnelle via un Detector plugin.
+type FrameAnalyzer struct {
+	config      AnalyzerConfig
+	backgrounds map[string]*backgroundModel
+	mutex       sync.Mutex
+}
+
+// NewFrameAnalyzer crée un analyseur de frames avec la configuration donnée.
+func NewFrameAnalyzer(config AnalyzerConfig) *FrameAnalyzer {
+	if config.Sensitivity <= 0 {
+		config = DefaultAnalyzerConfig()
+	}
+	return &FrameAnalyzer{
+		config:      config,
+		backgrounds: make(map[string]*backgroundModel),
+	}
+}
+
+// Run consomme frames jusqu'à fermeture du channel, pousse chaque détection
+// produite dans processor.ProcessDetection honorant les zones de la caméra.
+func (fa *FrameAnalyzer) Run(frames <-chan core.Frame, zones []core.Zone, processor core.EventProcessor) {
+	for frame := range frames {
+		for _, detection := range fa.AnalyzeFrame(frame, zones) {
+			processor.ProcessDetection(detection)
+		}
+	}
+}
+
+// AnalyzeFrame analyse une frame unique et retourne les détections produites.
+func (fa *FrameAnalyzer) AnalyzeFrame(frame core.Frame, zones []core.Zone) []core.Detection {
+	gray, width, height, err := decodeGrayscale(frame.Data)
+	if err != nil {
+		return nil
+	}
+
+	model := fa.backgroundModelFor(frame.CameraID, width, height)
+
+	mask := fa.foregroundMask(model, gray, width, height)
+	blobs := findBlobs(mask, width, height, fa.config.MinContourArea)
+
+	detections := make([]core.Detection, 0, len(blobs))
+	for _, blob := range blobs {
+		detection := core.Detection{
+			ID:         uuid.New().String(),
+			CameraID:   frame.CameraID,
+			Type:       core.DetectionTypeMotion,
+			Confidence: blob.confidence,
+			BBox:       blob.bbox,
+			Timestamp:  frame.Timestamp,
+			Metadata:   map[string]string{"source": "frame_analyzer"},
+		}
+		fa.classifyZones(&detection, zones)
+		detections = append(detections, detection)
+	}
+
+	if fa.config.ObjectDetector != nil {
+		objDetections, err := fa.config.ObjectDetector.Detect(frame)
+		if err == nil {
+			for _, detection := range objDetections {
+				fa.classifyZones(&detection, zones)
+				detections = append(detections, detection)
+			}
+		}
+	}
+
+	return detections
+}
+
+// classifyZones détermine si une détection tombe dans une zone active et,
+// si cette zone est taguée "intrusion", promeut la détection en Intrusion.
+func (fa *FrameAnalyzer) classifyZones(detection *core.Detection, zones []core.Zone) {
+	center := core.Point{
+		X: detection.BBox.X + detection.BBox.Width/2,
+		Y: detection.BBox.Y + detection.BBox.Height/2,
+	}
+
+	for _, zone := range zones {
+		if !zone.Active {
+			continue
+		}
+		if !pointInPolygon(center, zone.Points) {
+			continue
+		}
+		if detection.Metadata == nil {
+			detection.Metadata = make(map[string]string)
+		}
+		detection.Metadata["zone_id"] = zone.ID
+		if zone.Tag == "intrusion" {
+			detection.Type = core.DetectionTypeIntrusion
+		}
+	}
+}
+
+func (fa *FrameAnalyzer) backgroundModelFor(cameraID string, width, height int) *backgroundModel {
+	fa.mutex.Lock()
+	defer fa.mutex.Unlock()
+
+	model, exists := fa.backgrounds[cameraID]
+	if !exists || model.width != width || model.height != height {
+		model = &backgroundModel{
+			gray:   make([]float64, width*height),
+			width:  width,
+			height: height,
+		}
+		fa.backgrounds[cameraID] = model
+	}
+	return model
+}
+
+// foregroundMask calcule le masque binaire de mouvement par différence avec
+// le modèle de fond, puis met à jour ce modèle par moyenne mobile. La
+// première frame d'un modèle l'initialise et ne produit aucun mouvement.
+func (fa *FrameAnalyzer) foregroundMask(model *backgroundModel, gray []byte, width, height int) []bool {
+	mask := make([]bool, width*height)
+	rate := fa.config.BackgroundLearningRate
+
+	if !model.primed {
+		for i, pixel := range gray {
+			model.gray[i] = float64(pixel)
+		}
+		model.primed = true
+		return mask
+	}
+
+	for i, pixel := range gray {
+		current := float64(pixel)
+		diff := current - model.gray[i]
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > float64(fa.config.Sensitivity) {
+			mask[i] = true
+		}
+		model.gray[i] = model.gray[i]*(1-rate) + current*rate
+	}
+
+	return mask
+}
+
+type blob struct {
+	bbox       core.BoundingBox
+	confidence float32
+}
+
+// findBlobs effectue une détection de composantes connexes (flood-fill 4-connexe)
+// sur le masque binaire, retenant celles dont la surface excède minArea.
+func findBlobs(mask []bool, width, height, minArea int) []blob {
+	visited := make([]bool, len(mask))
+	var blobs []blob
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			idx := y*width + x
+			if !mask[idx] || visited[idx] {
+				continue
+			}
+
+			minX, minY, maxX, maxY, area := floodFill(mask, visited, width, height, x, y)
+			if area < minArea {
+				continue
+			}
+
+			confidence := float32(area) / float32(width*height)
+			if confidence > 1 {
+				confidence = 1
+			}
+
+			blobs = append(blobs, blob{
+				bbox: core.BoundingBox{
+					X:      minX,
+					Y:      minY,
+					Width:  maxX - minX + 1,
+					Height: maxY - minY + 1,
+				},
+				confidence: 0.5 + confidence*5, // surfaces plus grandes => confiance plus haute
+			})
+		}
+	}
+
+	return blobs
+}
+
+func floodFill(mask []bool, visited []bool, width, height, startX, startY int) (minX, minY, maxX, maxY, area int) {
+	minX, minY = startX, startY
+	maxX, maxY = startX, startY
+
+	stack := [][2]int{{startX, startY}}
+	visited[startY*width+startX] = true
+
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		x, y := p[0], p[1]
+		area++
+
+		if x < minX {
+			minX = x
+		}
+		if x > maxX {
+			maxX = x
+		}
+		if y < minY {
+			minY = y
+		}
+		if y > maxY {
+			maxY = y
+		}
+
+		neighbors := [][2]int{{x - 1, y}, {x + 1, y}, {x, y - 1}, {x, y + 1}}
+		for _, n := range neighbors {
+			nx, ny := n[0], n[1]
+			if nx < 0 || nx >= width || ny < 0 || ny >= height {
+				continue
+			}
+			nidx := ny*width + nx
+			if visited[nidx] || !mask[nidx] {
+				continue
+			}
+			visited[nidx] = true
+			stack = append(stack, [2]int{nx, ny})
+		}
+	}
+
+	return
+}
+
+// decodeGrayscale décode une frame JPEG et retourne ses niveaux de gris.
+func decodeGrayscale(data []byte) ([]byte, int, int, error) {
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	gray := make([]byte, width*height)
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := color.GrayModel.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.Gray)
+			gray[y*width+x] = c.Y
+		}
+	}
+
+	return gray, width, height, nil
+}
+
+// pointInPolygon teste l'appartenance d'un point à un polygone par la
+// méthode du ray casting.
+func pointInPolygon(p core.Point, polygon []core.Point) bool {
+	if len(polygon) < 3 {
+		return false
+	}
+
+	inside := false
+	j := len(polygon) - 1
+	for i := 0; i < len(polygon); i++ {
+		pi, pj := polygon[i], polygon[j]
+		if (pi.Y > p.Y) != (pj.Y > p.Y) &&
+			p.X < (pj.X-pi.X)*(p.Y-pi.Y)/(pj.Y-pi.Y)+pi.X {
+			inside = !inside
+		}
+		j = i
+	}
+
+	return inside
+}
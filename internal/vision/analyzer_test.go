@@ -0,0 +1,145 @@
+// internal/vision/analyzer_test.go
+package vision
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+	"time"
+
+	"surveillance-core/internal/core"
+)
+
+// encodeGrayJPEG encode une image width x height de niveau de gris uniforme
+// background, avec un carré de côté squareSide (valeur squareValue) centré
+// si squareSide > 0, en JPEG qualité maximale pour limiter le bruit de
+// compression dans les tests de seuil de mouvement.
+func encodeGrayJPEG(t *testing.T, width, height, background, squareSide, squareValue int) []byte {
+	t.Helper()
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetGray(x, y, color.Gray{Y: uint8(background)})
+		}
+	}
+
+	if squareSide > 0 {
+		startX, startY := (width-squareSide)/2, (height-squareSide)/2
+		for y := startY; y < startY+squareSide; y++ {
+			for x := startX; x < startX+squareSide; x++ {
+				img.SetGray(x, y, color.Gray{Y: uint8(squareValue)})
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100}); err != nil {
+		t.Fatalf("jpeg.Encode: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func testAnalyzerConfig() AnalyzerConfig {
+	return AnalyzerConfig{
+		Sensitivity:            50,
+		MinContourArea:         50,
+		BackgroundLearningRate: 0.5,
+	}
+}
+
+func TestFrameAnalyzer_AnalyzeFrame_DetectsMotion(t *testing.T) {
+	fa := NewFrameAnalyzer(testAnalyzerConfig())
+
+	baseline := core.Frame{
+		CameraID:  "cam1",
+		Data:      encodeGrayJPEG(t, 64, 64, 0, 0, 0),
+		Width:     64,
+		Height:    64,
+		Timestamp: time.Now(),
+	}
+	if detections := fa.AnalyzeFrame(baseline, nil); len(detections) != 0 {
+		t.Fatalf("expected no detections on the baseline frame, got %d", len(detections))
+	}
+
+	moving := core.Frame{
+		CameraID:  "cam1",
+		Data:      encodeGrayJPEG(t, 64, 64, 0, 20, 255),
+		Width:     64,
+		Height:    64,
+		Timestamp: time.Now(),
+	}
+	detections := fa.AnalyzeFrame(moving, nil)
+	if len(detections) != 1 {
+		t.Fatalf("expected 1 motion detection, got %d", len(detections))
+	}
+	if detections[0].Type != core.DetectionTypeMotion {
+		t.Errorf("expected DetectionTypeMotion, got %s", detections[0].Type)
+	}
+	if detections[0].CameraID != "cam1" {
+		t.Errorf("expected CameraID cam1, got %s", detections[0].CameraID)
+	}
+}
+
+func TestFrameAnalyzer_BackgroundModelsAreIsolatedPerCamera(t *testing.T) {
+	fa := NewFrameAnalyzer(testAnalyzerConfig())
+
+	// cam1 a déjà appris un fond clair ; cam2 démarre de zéro. Une frame
+	// identique pour cam2 ne doit pas être comparée au modèle de cam1.
+	fa.AnalyzeFrame(core.Frame{CameraID: "cam1", Data: encodeGrayJPEG(t, 32, 32, 200, 0, 0), Width: 32, Height: 32, Timestamp: time.Now()}, nil)
+
+	detections := fa.AnalyzeFrame(core.Frame{CameraID: "cam2", Data: encodeGrayJPEG(t, 32, 32, 200, 0, 0), Width: 32, Height: 32, Timestamp: time.Now()}, nil)
+	if len(detections) != 0 {
+		t.Fatalf("expected cam2's first frame to establish its own baseline with no detections, got %d", len(detections))
+	}
+}
+
+func TestFrameAnalyzer_ClassifyZones(t *testing.T) {
+	fa := NewFrameAnalyzer(testAnalyzerConfig())
+
+	zones := []core.Zone{
+		{
+			ID:     "zone-intrusion",
+			Active: true,
+			Tag:    "intrusion",
+			Points: []core.Point{{X: 0, Y: 0}, {X: 100, Y: 0}, {X: 100, Y: 100}, {X: 0, Y: 100}},
+		},
+	}
+
+	inside := core.Detection{Type: core.DetectionTypeMotion, BBox: core.BoundingBox{X: 10, Y: 10, Width: 10, Height: 10}}
+	fa.classifyZones(&inside, zones)
+	if inside.Type != core.DetectionTypeIntrusion {
+		t.Errorf("expected a detection inside an intrusion zone to be promoted, got %s", inside.Type)
+	}
+	if inside.Metadata["zone_id"] != "zone-intrusion" {
+		t.Errorf("expected zone_id metadata to be set, got %q", inside.Metadata["zone_id"])
+	}
+
+	outside := core.Detection{Type: core.DetectionTypeMotion, BBox: core.BoundingBox{X: 500, Y: 500, Width: 10, Height: 10}}
+	fa.classifyZones(&outside, zones)
+	if outside.Type != core.DetectionTypeMotion {
+		t.Errorf("expected a detection outside every zone to keep its original type, got %s", outside.Type)
+	}
+
+	inactiveZones := []core.Zone{{ID: "zone-off", Active: false, Tag: "intrusion", Points: zones[0].Points}}
+	untouched := core.Detection{Type: core.DetectionTypeMotion, BBox: core.BoundingBox{X: 10, Y: 10, Width: 10, Height: 10}}
+	fa.classifyZones(&untouched, inactiveZones)
+	if untouched.Type != core.DetectionTypeMotion {
+		t.Errorf("expected an inactive zone to be ignored, got %s", untouched.Type)
+	}
+}
+
+func TestPointInPolygon(t *testing.T) {
+	square := []core.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}
+
+	if !pointInPolygon(core.Point{X: 5, Y: 5}, square) {
+		t.Error("expected the center point to be inside the square")
+	}
+	if pointInPolygon(core.Point{X: 50, Y: 50}, square) {
+		t.Error("expected a far-away point to be outside the square")
+	}
+	if pointInPolygon(core.Point{X: 0, Y: 0}, []core.Point{{X: 0, Y: 0}, {X: 1, Y: 1}}) {
+		t.Error("expected a degenerate polygon with fewer than 3 points to match nothing")
+	}
+}
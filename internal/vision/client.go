@@ -2,23 +2,74 @@
 package vision
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"net/url"
 	"os"
-	//"surveillance-core/internal/core"
+	"strings"
+	"sync"
+
+	"surveillance-core/internal/core"
 )
 
+// URLAwareClient est un Client optionnel qui a besoin de l'URL réelle de la
+// caméra pour s'y connecter (RTSP, ONVIF), contrairement au mock ou au
+// client gRPC qui résolvent l'adresse autrement. ClientFactory s'appuie
+// dessus pour router StartStreamWithURL vers le bon backend selon le schéma
+// de l'URL.
+type URLAwareClient interface {
+	Client
+	StartStreamWithURL(ctx context.Context, cameraID, cameraURL string) (<-chan core.Frame, error)
+}
+
+// TransportAwareClient est un URLAwareClient optionnel qui sait en plus
+// appliquer des StreamOptions (transport RTP, codec préféré) issues de
+// core.CameraConfig. Implémenté par rtspClient, onvifClient et
+// ClientFactory ; internal/api/handlers.go s'en sert pour transmettre
+// CameraConfig.Transport/PreferredCodec au démarrage du stream.
+type TransportAwareClient interface {
+	URLAwareClient
+	StartStreamWithOptions(ctx context.Context, cameraID, cameraURL string, opts StreamOptions) (<-chan core.Frame, error)
+}
+
+// FrameDropCounter est un Client optionnel exposant, par caméra, le nombre de
+// frames perdues faute de place dans son buffer interne plutôt que de
+// bloquer le producteur (voir grpcClient.streamFrames). internal/api/handlers.go
+// s'en sert par type assertion pour enrichir GetStreamStatus sans alourdir
+// l'interface Client partagée par tous les backends.
+type FrameDropCounter interface {
+	DroppedFrameCount(cameraID string) (count int64, ok bool)
+}
+
+// HealthReporter est un Client optionnel qui surveille la santé du service
+// distant auquel il parle (grpcClient, via grpc.health.v1.Health/Check) en
+// plus de son état de connexion. cmd/server/main.go s'en sert pour
+// construire GRPCClientOptions.OnHealthChange et propager les transitions à
+// core.EventProcessor.SetVisionHealthy.
+type HealthReporter interface {
+	IsHealthy() bool
+}
+
 // ClientType represents the type of vision client to create
 type ClientType string
 
 const (
-	ClientTypeMock ClientType = "mock"
-	ClientTypeGRPC ClientType = "grpc"
+	ClientTypeMock  ClientType = "mock"
+	ClientTypeGRPC  ClientType = "grpc"
+	ClientTypeRTSP  ClientType = "rtsp"
+	ClientTypeONVIF ClientType = "onvif"
+	// ClientTypeAuto sélectionne dynamiquement le backend par caméra, selon
+	// le schéma de son URL (voir ClientFactory).
+	ClientTypeAuto ClientType = "auto"
 )
 
 // ClientConfig holds configuration for vision clients
 type ClientConfig struct {
-	Type        ClientType `json:"type"`
-	GRPCAddress string     `json:"grpc_address"`
+	Type        ClientType        `json:"type"`
+	GRPCAddress string            `json:"grpc_address"`
+	GRPC        GRPCClientOptions `json:"grpc"`
+	RTSP        RTSPClientConfig  `json:"rtsp"`
 }
 
 // DefaultClientConfig returns default configuration
@@ -26,6 +77,8 @@ func DefaultClientConfig() *ClientConfig {
 	return &ClientConfig{
 		Type:        ClientTypeMock, // Default to mock for backward compatibility
 		GRPCAddress: "localhost:50051",
+		GRPC:        DefaultGRPCClientOptions(),
+		RTSP:        DefaultRTSPClientConfig(),
 	}
 }
 
@@ -48,7 +101,13 @@ func NewClient(config *ClientConfig) Client {
 
 	switch config.Type {
 	case ClientTypeGRPC:
-		return NewGRPCClient(config.GRPCAddress)
+		return NewGRPCClient(config.GRPCAddress, config.GRPC)
+	case ClientTypeRTSP:
+		return NewRTSPClient(config.RTSP)
+	case ClientTypeONVIF:
+		return NewONVIFClient(config.RTSP)
+	case ClientTypeAuto:
+		return NewClientFactory(config)
 	case ClientTypeMock:
 		return NewMockClient()
 	default:
@@ -56,3 +115,158 @@ func NewClient(config *ClientConfig) Client {
 		return NewMockClient()
 	}
 }
+
+// ClientFactory implémente Client en routant chaque caméra vers le backend
+// approprié selon le schéma de son URL (rtsp://, rtmp://, onvif://,
+// mock://), au lieu d'imposer un unique ClientType à toute l'application
+// (voir cmd/server/main.go, qui utilisait NewMockClient() en dur). Le
+// gRPC reste opt-in explicite : il ne correspond à aucun schéma d'URL de
+// caméra, les caméras qui doivent y passer utilisent ClientTypeGRPC
+// directement.
+type ClientFactory struct {
+	mutex      sync.RWMutex
+	rtsp       Client
+	onvif      Client
+	mock       Client
+	assignment map[string]Client // cameraID -> backend choisi à StartStreamWithURL
+}
+
+// NewClientFactory crée un ClientFactory et construit immédiatement ses trois
+// backends concrets (rtsp, onvif, mock) à partir de config.
+func NewClientFactory(config *ClientConfig) *ClientFactory {
+	if config == nil {
+		config = DefaultClientConfig()
+	}
+	return &ClientFactory{
+		rtsp:       NewRTSPClient(config.RTSP),
+		onvif:      NewONVIFClient(config.RTSP),
+		mock:       NewMockClient(),
+		assignment: make(map[string]Client),
+	}
+}
+
+func (f *ClientFactory) backendForURL(cameraURL string) (Client, error) {
+	u, err := url.Parse(cameraURL)
+	if err != nil {
+		return nil, fmt.Errorf("URL caméra invalide %q: %w", cameraURL, err)
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "rtsp", "rtmp":
+		return f.rtsp, nil
+	case "onvif":
+		return f.onvif, nil
+	case "mock", "":
+		return f.mock, nil
+	default:
+		return nil, fmt.Errorf("schéma d'URL caméra non supporté: %q", u.Scheme)
+	}
+}
+
+func (f *ClientFactory) StartStream(ctx context.Context, cameraID string) (<-chan core.Frame, error) {
+	f.mutex.RLock()
+	backend, ok := f.assignment[cameraID]
+	f.mutex.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("aucune URL connue pour la caméra %s: utiliser StartStreamWithURL", cameraID)
+	}
+	return backend.StartStream(ctx, cameraID)
+}
+
+// StartStreamWithURL choisit le backend à partir du schéma de cameraURL,
+// mémorise l'association pour les appels StopStream/GetStreamStatus
+// suivants, puis délègue le démarrage.
+func (f *ClientFactory) StartStreamWithURL(ctx context.Context, cameraID, cameraURL string) (<-chan core.Frame, error) {
+	backend, err := f.backendForURL(cameraURL)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mutex.Lock()
+	f.assignment[cameraID] = backend
+	f.mutex.Unlock()
+
+	if urlAware, ok := backend.(URLAwareClient); ok {
+		return urlAware.StartStreamWithURL(ctx, cameraID, cameraURL)
+	}
+	return backend.StartStream(ctx, cameraID)
+}
+
+// StartStreamWithOptions choisit le backend comme StartStreamWithURL, en
+// transmettant en plus opts quand le backend choisi est lui-même
+// TransportAwareClient (rtsp, onvif) ; sinon opts est silencieusement
+// ignoré (mock).
+func (f *ClientFactory) StartStreamWithOptions(ctx context.Context, cameraID, cameraURL string, opts StreamOptions) (<-chan core.Frame, error) {
+	backend, err := f.backendForURL(cameraURL)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mutex.Lock()
+	f.assignment[cameraID] = backend
+	f.mutex.Unlock()
+
+	if transportAware, ok := backend.(TransportAwareClient); ok {
+		return transportAware.StartStreamWithOptions(ctx, cameraID, cameraURL, opts)
+	}
+	if urlAware, ok := backend.(URLAwareClient); ok {
+		return urlAware.StartStreamWithURL(ctx, cameraID, cameraURL)
+	}
+	return backend.StartStream(ctx, cameraID)
+}
+
+func (f *ClientFactory) StopStream(cameraID string) error {
+	f.mutex.Lock()
+	backend, ok := f.assignment[cameraID]
+	delete(f.assignment, cameraID)
+	f.mutex.Unlock()
+
+	if !ok {
+		return fmt.Errorf("aucun backend assigné à la caméra %s", cameraID)
+	}
+	return backend.StopStream(cameraID)
+}
+
+func (f *ClientFactory) GetStreamStatus(cameraID string) StreamStatus {
+	f.mutex.RLock()
+	backend, ok := f.assignment[cameraID]
+	f.mutex.RUnlock()
+
+	if !ok {
+		return StreamStatusStopped
+	}
+	return backend.GetStreamStatus(cameraID)
+}
+
+// IsConnected retourne true si au moins un des backends sous-jacents a un
+// flux actif.
+func (f *ClientFactory) IsConnected() bool {
+	return f.rtsp.IsConnected() || f.onvif.IsConnected() || f.mock.IsConnected()
+}
+
+// Close arrête les trois backends sous-jacents, même si l'un d'eux échoue à
+// se fermer dans le délai imparti par ctx.
+func (f *ClientFactory) Close(ctx context.Context) error {
+	var errs []error
+	for _, backend := range []Client{f.rtsp, f.onvif, f.mock} {
+		if err := backend.Close(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("arrêt du ClientFactory: %v", errs)
+	}
+	return nil
+}
+
+// SetAlertSink relaie aux backends qui supportent les alertes système
+// (rtsp, onvif), par type assertion optionnelle comme pour StatefulDetector
+// dans internal/core.
+func (f *ClientFactory) SetAlertSink(sink func(core.Alert)) {
+	for _, backend := range []Client{f.rtsp, f.onvif} {
+		if sinkable, ok := backend.(interface{ SetAlertSink(func(core.Alert)) }); ok {
+			sinkable.SetAlertSink(sink)
+		}
+	}
+}
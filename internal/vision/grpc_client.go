@@ -3,129 +3,540 @@ package vision
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
-	"surveillance-core/internal/core"
-	pb "surveillance-core/internal/vision/proto"
+	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"surveillance-core/internal/core"
+	"surveillance-core/internal/metrics"
+	pb "surveillance-core/internal/vision/proto"
+
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 )
 
+// GRPCClientOptions configure le transport gRPC vers le service vision
+// (keepalive, backoff de (re)connexion, retries par RPC, TLS), en
+// remplacement des constantes codées en dur qu'utilisaient auparavant
+// connect() et le ticker naïf de monitorConnection().
+type GRPCClientOptions struct {
+	// KeepaliveTime est l'intervalle entre deux pings keepalive envoyés sur
+	// une connexion inactive.
+	KeepaliveTime time.Duration
+	// KeepaliveTimeout est le délai d'attente d'un pong avant de considérer
+	// la connexion perdue.
+	KeepaliveTimeout time.Duration
+	// PermitWithoutStream autorise l'envoi de pings même sans RPC en cours,
+	// nécessaire pour détecter une coupure entre deux streams de frames.
+	PermitWithoutStream bool
+
+	// InitialBackoff et MaxBackoff bornent le backoff exponentiel de
+	// grpc.ConnectParams entre deux tentatives de connexion du ClientConn.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// MaxRetryAttempts borne le nombre de tentatives par RPC, appliqué via
+	// le service config de retry transparent gRPC (voir retryServiceConfig).
+	MaxRetryAttempts int
+
+	// CallTimeout borne chaque appel RPC individuel (StartStream, StopStream,
+	// GetStreamStatus, et chaque Check de healthMonitor), indépendamment du
+	// backoff de connexion. GetHealth (voir handleHealthCheck) garde son
+	// délai fixe de 5 secondes.
+	CallTimeout time.Duration
+
+	// HealthCheckInterval cadence les appels périodiques au service de santé
+	// standard gRPC (grpc.health.v1.Health/Check, voir healthMonitor). Zéro
+	// désactive la surveillance périodique ; HealthCheck() reste utilisable
+	// à la demande via l'ancien GetHealth (voir handleHealthCheck).
+	HealthCheckInterval time.Duration
+
+	// OnHealthChange, si non nil, est appelé depuis run() à chaque
+	// changement d'état de santé détecté par healthMonitor (après épuisement
+	// des tentatives de MaxRetryAttempts). Utilisé par cmd/server/main.go
+	// pour mettre en pause les détecteurs de l'EventProcessor quand le
+	// service vision est injoignable (voir core.EventProcessor.SetVisionHealthy).
+	OnHealthChange func(healthy bool)
+
+	// TransportCredentials remplace, si non nil, les credentials non
+	// chiffrées par défaut (TLS/mTLS vers le service vision).
+	TransportCredentials credentials.TransportCredentials
+
+	// PerRPCCredentials, si non nil, est attaché à chaque appel RPC (voir
+	// grpc.WithPerRPCCredentials) en plus de TransportCredentials. Utilisé
+	// par NewRemoteGRPCClient pour porter un RemoteToken signé vers un
+	// service vision distant.
+	PerRPCCredentials credentials.PerRPCCredentials
+}
+
+// DefaultGRPCClientOptions retourne des réglages keepalive/backoff/retry
+// raisonnables pour un service vision co-localisé ou sur réseau local.
+func DefaultGRPCClientOptions() GRPCClientOptions {
+	return GRPCClientOptions{
+		KeepaliveTime:       20 * time.Second,
+		KeepaliveTimeout:    5 * time.Second,
+		PermitWithoutStream: true,
+		InitialBackoff:      1 * time.Second,
+		MaxBackoff:          30 * time.Second,
+		MaxRetryAttempts:    3,
+		CallTimeout:         10 * time.Second,
+		HealthCheckInterval: 30 * time.Second,
+	}
+}
+
+// GRPCClientOptionsFromConfig traduit un core.VisionServiceConfig en
+// GRPCClientOptions, pour les appelants (cmd/server/main.go) qui construisent
+// le client gRPC du service vision à partir de la configuration chargée
+// plutôt que de DefaultGRPCClientOptions(). Les réglages keepalive n'ayant
+// pas d'équivalent dans VisionServiceConfig, ils gardent leur valeur par
+// défaut.
+func GRPCClientOptionsFromConfig(cfg core.VisionServiceConfig) (GRPCClientOptions, error) {
+	opts := DefaultGRPCClientOptions()
+
+	if cfg.Timeout > 0 {
+		opts.CallTimeout = cfg.Timeout
+	}
+	if cfg.MaxRetries > 0 {
+		opts.MaxRetryAttempts = cfg.MaxRetries
+	}
+	if cfg.RetryInterval > 0 {
+		opts.InitialBackoff = cfg.RetryInterval
+	}
+	if cfg.HealthCheckInterval > 0 {
+		opts.HealthCheckInterval = cfg.HealthCheckInterval
+	}
+
+	creds, err := credentialsFromConfig(cfg.TLSCfg)
+	if err != nil {
+		return GRPCClientOptions{}, fmt.Errorf("configuration TLS du service vision: %w", err)
+	}
+	opts.TransportCredentials = creds
+
+	return opts, nil
+}
+
+// retryServiceConfig construit un service config gRPC JSON appliquant une
+// retryPolicy à toutes les méthodes ("name": [{}]), puisque le service vision
+// n'expose qu'un seul VisionServiceClient et que toutes ses RPCs (StartStream,
+// StopStream, GetStreamStatus, GetHealth) tolèrent un retry sur UNAVAILABLE.
+func retryServiceConfig(opts GRPCClientOptions) string {
+	return fmt.Sprintf(`{
+		"methodConfig": [{
+			"name": [{}],
+			"retryPolicy": {
+				"MaxAttempts": %d,
+				"InitialBackoff": "%gs",
+				"MaxBackoff": "%gs",
+				"BackoffMultiplier": 2,
+				"RetryableStatusCodes": ["UNAVAILABLE"]
+			}
+		}]
+	}`, opts.MaxRetryAttempts, opts.InitialBackoff.Seconds(), opts.MaxBackoff.Seconds())
+}
+
+// grpcClient pilote la connexion et les streams vers le service vision au
+// travers d'une unique goroutine run(), seule à lire/écrire conn, client et
+// streams. Les méthodes publiques (StartStream, StopStream,
+// GetStreamStatus, DroppedFrameCount, HealthCheck, Close) sont de simples
+// enveloppes qui postent une requête typée sur le canal correspondant et
+// attendent la réponse : plus de mutex à tenir dans le bon ordre, plus de
+// lecture de stream.status concurrente à son écriture. connected et
+// visionHealthy sont les seules exceptions : des flags atomiques mis à jour
+// uniquement par run(), lus sans passer par un canal pour qu'IsConnected()
+// et IsHealthy() restent des appels bon marché.
 type grpcClient struct {
-	conn         *grpc.ClientConn
-	client       pb.VisionServiceClient
-	address      string
-	streams      map[string]*grpcStream
-	mutex        sync.RWMutex
-	ctx          context.Context
-	cancel       context.CancelFunc
-	connected    bool
-	connectMutex sync.Mutex
+	address string
+	opts    GRPCClientOptions
+
+	conn   *grpc.ClientConn       // possédé exclusivement par run()
+	client pb.VisionServiceClient // idem
+	streams map[string]*grpcStream // idem
+
+	startStreamCh  chan startStreamReq
+	stopStreamCh   chan stopStreamReq
+	statusCh       chan statusReq
+	healthCh       chan healthReq
+	connStateCh    chan connectivity.State
+	healthResultCh chan bool
+	shutdownCh     chan shutdownReq
+
+	connected     int32 // accédé via atomic ; 1 = connecté, 0 = non connecté
+	visionHealthy int32 // accédé via atomic ; 1 = dernier Check() réussi, 0 = sinon
+
+	// closeMutex protège closed : les méthodes publiques le lisent pour
+	// rejeter tout envoi postérieur à Close, dont le select loop de run()
+	// a déjà retourné, et qui bloquerait sinon indéfiniment sur un canal
+	// que plus personne ne lit (voir eventProcessor.closeMutex pour le
+	// même motif).
+	closeMutex sync.RWMutex
+	closed     bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup // goroutines streamFrames en cours
 }
 
+// ErrGRPCClientClosed est renvoyée par les méthodes publiques de grpcClient
+// appelées après que Close a terminé, plutôt que de bloquer sur un canal
+// que run() ne lit plus.
+var ErrGRPCClientClosed = fmt.Errorf("grpc_client: client fermé")
+
 type grpcStream struct {
-	cameraID   string
-	framesChan chan core.Frame
-	stopChan   chan struct{}
-	status     StreamStatus
-	cancel     context.CancelFunc
+	cameraID      string
+	framesChan    chan core.Frame
+	status        StreamStatus
+	cancel        context.CancelFunc
+	droppedFrames int64 // accédé via atomic : écrit par streamFrames, lu par run()
 }
 
-// NewGRPCClient creates a new gRPC client for the vision service
-func NewGRPCClient(address string) Client {
+// startStreamReq demande à run() de démarrer (ou réutiliser) le stream de
+// cameraID et répond sur reply avec le channel de frames ou une erreur.
+type startStreamReq struct {
+	ctx      context.Context
+	cameraID string
+	reply    chan startStreamResult
+}
+
+type startStreamResult struct {
+	frames <-chan core.Frame
+	err    error
+}
+
+// stopStreamReq demande à run() d'arrêter le stream de cameraID.
+type stopStreamReq struct {
+	cameraID string
+	reply    chan error
+}
+
+// statusReq demande à run() le statut courant (et le compteur de frames
+// perdues) du stream de cameraID.
+type statusReq struct {
+	cameraID string
+	reply    chan statusResult
+}
+
+type statusResult struct {
+	status    StreamStatus
+	dropped   int64
+	hasStream bool
+}
+
+// healthReq demande à run() d'appeler GetHealth sur le service vision.
+type healthReq struct {
+	reply chan error
+}
+
+// shutdownReq demande à run() de drainer les streams actifs, fermer la
+// connexion et terminer la boucle.
+type shutdownReq struct {
+	ctx   context.Context
+	reply chan error
+}
+
+// NewGRPCClient creates a new gRPC client for the vision service. A zero-value
+// opts.KeepaliveTime falls back to DefaultGRPCClientOptions(), mirroring
+// NewRTSPClient's handling of a zero-value RTSPClientConfig.
+func NewGRPCClient(address string, opts GRPCClientOptions) Client {
+	if opts.KeepaliveTime <= 0 {
+		opts = DefaultGRPCClientOptions()
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 
 	client := &grpcClient{
-		address: address,
-		streams: make(map[string]*grpcStream),
-		ctx:     ctx,
-		cancel:  cancel,
+		address:        address,
+		opts:           opts,
+		streams:        make(map[string]*grpcStream),
+		startStreamCh:  make(chan startStreamReq),
+		stopStreamCh:   make(chan stopStreamReq),
+		statusCh:       make(chan statusReq),
+		healthCh:       make(chan healthReq),
+		connStateCh:    make(chan connectivity.State),
+		healthResultCh: make(chan bool),
+		shutdownCh:     make(chan shutdownReq),
+		ctx:            ctx,
+		cancel:         cancel,
 	}
 
-	// Try to connect immediately
-	go client.connect()
+	go client.run()
 
 	return client
 }
 
-func (gc *grpcClient) connect() error {
-	gc.connectMutex.Lock()
-	defer gc.connectMutex.Unlock()
+// NewRemoteGRPCClient crée un grpcClient vers un service vision distant
+// identifié par address ("host:port"), en attachant token comme
+// PerRPCCredentials sur chaque appel (voir remoteTokenCredentials) et tlsCfg
+// comme TransportCredentials (voir credentialsFromConfig). Utilisé par
+// internal/api.Handler pour fédérer une caméra dont CameraConfig.RemoteURL
+// (ou Hostname/Port) pointe vers un edge box distinct du service vision
+// local ; tlsCfg vient de CameraConfig.RemoteTLS. tlsCfg.Enabled == false
+// laisse le canal en clair (déploiements intra-cluster), mais dans ce cas le
+// jeton bearer n'est jamais exigé sur un transport sécurisé (voir
+// NewRemoteTokenCredentials) : c'est un choix explicite de l'opérateur, pas
+// un défaut silencieux.
+func NewRemoteGRPCClient(address, token string, tlsCfg core.TLSCfg) (Client, error) {
+	opts := DefaultGRPCClientOptions()
+
+	creds, err := credentialsFromConfig(tlsCfg)
+	if err != nil {
+		return nil, fmt.Errorf("configuration TLS pour le service vision distant %s: %w", address, err)
+	}
+	opts.TransportCredentials = creds
+	opts.PerRPCCredentials = NewRemoteTokenCredentials(token, tlsCfg.Enabled)
 
-	if gc.connected {
-		return nil
+	return NewGRPCClient(address, opts), nil
+}
+
+// run est la seule goroutine qui lit ou écrit gc.conn, gc.client et
+// gc.streams : toutes les requêtes publiques transitent par ses canaux et
+// sont traitées séquentiellement, ce qui élimine les races qu'imposait
+// auparavant de tenir connectMutex puis mutex dans le bon ordre. Une
+// reconnexion (dial()) bloque la boucle le temps de la tentative, ce qui est
+// voulu : aucune autre commande ne doit voir un état partiellement connecté.
+func (gc *grpcClient) run() {
+	gc.dial()
+
+	for {
+		select {
+		case req := <-gc.startStreamCh:
+			frames, err := gc.handleStartStream(req)
+			req.reply <- startStreamResult{frames: frames, err: err}
+
+		case req := <-gc.stopStreamCh:
+			req.reply <- gc.handleStopStream(req.cameraID)
+
+		case req := <-gc.statusCh:
+			req.reply <- gc.handleStatus(req.cameraID)
+
+		case req := <-gc.healthCh:
+			req.reply <- gc.handleHealthCheck()
+
+		case state := <-gc.connStateCh:
+			gc.handleConnStateChanged(state)
+
+		case healthy := <-gc.healthResultCh:
+			gc.handleHealthChanged(healthy)
+
+		case req := <-gc.shutdownCh:
+			req.reply <- gc.handleShutdown(req.ctx)
+			return
+		}
+	}
+}
+
+// dial établit gc.conn/gc.client si nécessaire. N'est appelé que depuis
+// run(), jamais directement par une méthode publique.
+func (gc *grpcClient) dial() {
+	if gc.conn != nil {
+		return
 	}
 
 	log.Printf("🔌 Connecting to vision service at %s...", gc.address)
 
-	// Create connection with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	conn, err := grpc.DialContext(ctx, gc.address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	creds := gc.opts.TransportCredentials
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
 		grpc.WithBlock(), // Wait for connection
-	)
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                gc.opts.KeepaliveTime,
+			Timeout:             gc.opts.KeepaliveTimeout,
+			PermitWithoutStream: gc.opts.PermitWithoutStream,
+		}),
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff: backoff.Config{
+				BaseDelay:  gc.opts.InitialBackoff,
+				Multiplier: backoff.DefaultConfig.Multiplier,
+				Jitter:     backoff.DefaultConfig.Jitter,
+				MaxDelay:   gc.opts.MaxBackoff,
+			},
+		}),
+		grpc.WithDefaultServiceConfig(retryServiceConfig(gc.opts)),
+	}
+	if gc.opts.PerRPCCredentials != nil {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(gc.opts.PerRPCCredentials))
+	}
+
+	conn, err := grpc.DialContext(ctx, gc.address, dialOpts...)
 	if err != nil {
 		log.Printf("❌ Failed to connect to vision service: %v", err)
-		return err
+		return
 	}
 
 	gc.conn = conn
 	gc.client = pb.NewVisionServiceClient(conn)
-	gc.connected = true
+	atomic.StoreInt32(&gc.connected, 1)
 
 	log.Printf("✅ Connected to vision service at %s", gc.address)
 
-	// Start health monitoring
-	go gc.monitorConnection()
+	go gc.watchConnState(conn, conn.GetState())
 
-	return nil
+	if gc.opts.HealthCheckInterval > 0 {
+		atomic.StoreInt32(&gc.visionHealthy, 1)
+		go gc.healthMonitor(gc.ctx, conn)
+	}
+}
+
+// watchConnState tourne dans sa propre goroutine (une par connexion établie)
+// et ne fait que relayer chaque transition d'état sur gc.connStateCh, pour
+// que gc.conn/gc.client restent manipulés uniquement par run(). Se termine
+// quand gc.ctx est annulé ou que la connexion passe Shutdown.
+func (gc *grpcClient) watchConnState(conn *grpc.ClientConn, state connectivity.State) {
+	for conn.WaitForStateChange(gc.ctx, state) {
+		state = conn.GetState()
+		select {
+		case gc.connStateCh <- state:
+		case <-gc.ctx.Done():
+			return
+		}
+		if state == connectivity.Shutdown {
+			return
+		}
+	}
+}
+
+// handleConnStateChanged loggue la transition et tient à jour le flag
+// connected lu par IsConnected(). Le ClientConn se reconnecte déjà tout seul
+// selon le backoff de grpc.ConnectParams ; run() n'a pas besoin de redialer
+// manuellement ici.
+func (gc *grpcClient) handleConnStateChanged(state connectivity.State) {
+	log.Printf("🔄 Vision service connection state: %s", state)
+
+	switch state {
+	case connectivity.Ready, connectivity.Idle, connectivity.Connecting:
+		atomic.StoreInt32(&gc.connected, 1)
+	default:
+		atomic.StoreInt32(&gc.connected, 0)
+	}
 }
 
-func (gc *grpcClient) monitorConnection() {
-	ticker := time.NewTicker(30 * time.Second)
+// healthMonitor interroge périodiquement le service de santé standard gRPC
+// (grpc.health.v1.Health/Check), plutôt que la RPC GetHealth propriétaire
+// utilisée par handleHealthCheck, et publie chaque résultat sur
+// gc.healthResultCh pour que run() reste la seule goroutine à décider de
+// l'état de santé courant. Tourne dans sa propre goroutine (une par
+// connexion établie) et se termine quand gc.ctx est annulé.
+func (gc *grpcClient) healthMonitor(ctx context.Context, conn *grpc.ClientConn) {
+	healthClient := grpc_health_v1.NewHealthClient(conn)
+	ticker := time.NewTicker(gc.opts.HealthCheckInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-gc.ctx.Done():
-			return
 		case <-ticker.C:
-			if !gc.IsConnected() {
-				log.Printf("🔄 Connection lost, attempting reconnection...")
-				gc.connected = false
-				go gc.connect()
+			healthy := gc.checkHealthWithRetry(ctx, healthClient)
+			select {
+			case gc.healthResultCh <- healthy:
+			case <-ctx.Done():
+				return
 			}
+		case <-ctx.Done():
+			return
 		}
 	}
 }
 
-func (gc *grpcClient) StartStream(cameraID string) (<-chan core.Frame, error) {
-	if !gc.IsConnected() {
-		if err := gc.connect(); err != nil {
-			return nil, fmt.Errorf("failed to connect to vision service: %w", err)
+// checkHealthWithRetry appelle Check("") jusqu'à MaxRetryAttempts fois avec
+// un backoff exponentiel plus jitter entre chaque tentative (même doublement
+// borné par MaxBackoff que waitBeforeResubscribe, qui n'ajoute pas de
+// jitter), et retourne true dès qu'une réponse SERVING
+// est obtenue. N'épuise ses tentatives que pour un échec : un seul Check qui
+// réussit suffit.
+func (gc *grpcClient) checkHealthWithRetry(ctx context.Context, healthClient grpc_health_v1.HealthClient) bool {
+	start := time.Now()
+	defer func() { metrics.VisionHealthCheckDuration.Observe(time.Since(start).Seconds()) }()
+
+	delay := gc.opts.InitialBackoff
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			metrics.VisionHealthCheckRetriesTotal.Inc()
+		}
+
+		callCtx, cancel := context.WithTimeout(ctx, gc.opts.CallTimeout)
+		resp, err := healthClient.Check(callCtx, &grpc_health_v1.HealthCheckRequest{})
+		cancel()
+
+		if err == nil && resp.Status == grpc_health_v1.HealthCheckResponse_SERVING {
+			return true
+		}
+		if attempt+1 >= gc.opts.MaxRetryAttempts {
+			return false
+		}
+
+		jitter := time.Duration(rand.Int63n(int64(delay)/2 + 1))
+		select {
+		case <-time.After(delay + jitter):
+		case <-ctx.Done():
+			return false
+		}
+
+		delay *= 2
+		if delay > gc.opts.MaxBackoff {
+			delay = gc.opts.MaxBackoff
 		}
 	}
+}
 
-	gc.mutex.Lock()
-	defer gc.mutex.Unlock()
+// handleHealthChanged met à jour gc.visionHealthy et prévient
+// opts.OnHealthChange, mais seulement quand l'état rapporté diffère du
+// précédent : les Check() réguliers qui confirment le même état ne doivent
+// pas redéclencher une pause/reprise déjà en place côté EventProcessor.
+func (gc *grpcClient) handleHealthChanged(healthy bool) {
+	var newValue int32
+	if healthy {
+		newValue = 1
+	}
 
-	// Check if stream already exists
-	if stream, exists := gc.streams[cameraID]; exists {
+	if atomic.SwapInt32(&gc.visionHealthy, newValue) == newValue {
+		return
+	}
+	metrics.VisionHealthStatus.Set(float64(newValue))
+
+	if healthy {
+		log.Printf("✅ Vision service health restored at %s", gc.address)
+	} else {
+		log.Printf("❌ Vision service at %s failed health check after %d attempts", gc.address, gc.opts.MaxRetryAttempts)
+	}
+
+	if gc.opts.OnHealthChange != nil {
+		gc.opts.OnHealthChange(healthy)
+	}
+}
+
+func (gc *grpcClient) handleStartStream(req startStreamReq) (<-chan core.Frame, error) {
+	if gc.conn == nil {
+		gc.dial()
+		if gc.conn == nil {
+			return nil, fmt.Errorf("failed to connect to vision service")
+		}
+	}
+
+	if stream, exists := gc.streams[req.cameraID]; exists {
 		if stream.status == StreamStatusActive {
 			return stream.framesChan, nil
 		}
 		// Stop existing stream if it's in error state
-		gc.stopStreamInternal(cameraID)
+		gc.stopStreamLocked(req.cameraID)
 	}
 
-	// Create gRPC request
-	req := &pb.StreamRequest{
-		CameraId:  cameraID,
+	pbReq := &pb.StreamRequest{
+		CameraId:  req.cameraID,
 		CameraUrl: "test://pattern", // Use test pattern for now
 		Config: &pb.StreamConfig{
 			Width:  640,
@@ -135,124 +546,62 @@ func (gc *grpcClient) StartStream(cameraID string) (<-chan core.Frame, error) {
 		},
 	}
 
-	// Call StartStream on the C++ service
-	resp, err := gc.client.StartStream(context.Background(), req)
+	callCtx, callCancel := context.WithTimeout(context.Background(), gc.opts.CallTimeout)
+	resp, err := gc.client.StartStream(callCtx, pbReq)
+	callCancel()
 	if err != nil {
 		return nil, fmt.Errorf("failed to start stream: %w", err)
 	}
-
 	if resp.Status != "success" {
 		return nil, fmt.Errorf("stream start failed: %s", resp.Message)
 	}
 
-	// Create stream state
-	ctx, cancel := context.WithCancel(gc.ctx)
+	streamCtx, cancel := context.WithCancel(req.ctx)
 	stream := &grpcStream{
-		cameraID:   cameraID,
+		cameraID:   req.cameraID,
 		framesChan: make(chan core.Frame, 10),
-		stopChan:   make(chan struct{}),
 		status:     StreamStatusActive,
 		cancel:     cancel,
 	}
+	gc.streams[req.cameraID] = stream
 
-	gc.streams[cameraID] = stream
+	gc.wg.Add(1)
+	go gc.streamFrames(streamCtx, stream, pbReq)
 
-	// Start frame streaming goroutine
-	go gc.streamFrames(ctx, stream)
-
-	log.Printf("✅ Stream started for camera: %s (stream_id: %s)", cameraID, resp.StreamId)
+	log.Printf("✅ Stream started for camera: %s (stream_id: %s)", req.cameraID, resp.StreamId)
 
 	return stream.framesChan, nil
 }
 
-func (gc *grpcClient) streamFrames(ctx context.Context, stream *grpcStream) {
-	defer close(stream.framesChan)
-
-	// For Phase 2.2, we'll simulate frames since we don't have real video streaming yet
-	ticker := time.NewTicker(time.Second / 15) // 15 FPS
-	defer ticker.Stop()
-
-	frameCounter := 0
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case <-stream.stopChan:
-			return
-		case <-ticker.C:
-			// Create simulated frame
-			frame := core.Frame{
-				CameraID:  stream.cameraID,
-				Data:      gc.generateMockFrameData(),
-				Width:     640,
-				Height:    480,
-				Format:    "bgr",
-				Timestamp: time.Now(),
-				Size:      640 * 480 * 3,
-			}
-
-			// Send frame (non-blocking)
-			select {
-			case stream.framesChan <- frame:
-				frameCounter++
-				if frameCounter%150 == 0 { // Every 10 seconds at 15fps
-					log.Printf("📹 Streaming frame %d for camera %s", frameCounter, stream.cameraID)
-				}
-			default:
-				// Channel full, drop frame
-			}
-		}
-	}
-}
-
-func (gc *grpcClient) generateMockFrameData() []byte {
-	// Generate mock BGR frame data (640x480x3)
-	size := 640 * 480 * 3
-	data := make([]byte, size)
-
-	// Fill with a simple pattern for Phase 2.2
-	for i := 0; i < size; i += 3 {
-		data[i] = 100   // B
-		data[i+1] = 150 // G
-		data[i+2] = 200 // R
-	}
-
-	return data
-}
-
-func (gc *grpcClient) StopStream(cameraID string) error {
-	if !gc.IsConnected() {
+func (gc *grpcClient) handleStopStream(cameraID string) error {
+	if gc.conn == nil {
 		return fmt.Errorf("not connected to vision service")
 	}
-
-	gc.mutex.Lock()
-	defer gc.mutex.Unlock()
-
-	return gc.stopStreamInternal(cameraID)
+	return gc.stopStreamLocked(cameraID)
 }
 
-func (gc *grpcClient) stopStreamInternal(cameraID string) error {
+// stopStreamLocked arrête le stream de cameraID. N'est appelée que depuis
+// run() (directement ou via handleStartStream/handleShutdown), jamais en
+// dehors : le nom reflète l'invariant hérité de l'ancien mutex plutôt qu'un
+// verrou réellement pris ici.
+func (gc *grpcClient) stopStreamLocked(cameraID string) error {
 	stream, exists := gc.streams[cameraID]
 	if !exists {
 		return fmt.Errorf("stream not found for camera: %s", cameraID)
 	}
 
-	// Call StopStream on the C++ service
-	req := &pb.StopRequest{
-		CameraId: cameraID,
-	}
+	req := &pb.StopRequest{CameraId: cameraID}
 
-	resp, err := gc.client.StopStream(context.Background(), req)
+	callCtx, callCancel := context.WithTimeout(context.Background(), gc.opts.CallTimeout)
+	resp, err := gc.client.StopStream(callCtx, req)
+	callCancel()
 	if err != nil {
 		log.Printf("⚠️ Warning: failed to stop stream on server: %v", err)
 	} else if resp.Status != "success" {
 		log.Printf("⚠️ Warning: server reported error stopping stream: %s", resp.Message)
 	}
 
-	// Stop local stream
 	stream.cancel()
-	close(stream.stopChan)
 	delete(gc.streams, cameraID)
 
 	log.Printf("✅ Stream stopped for camera: %s", cameraID)
@@ -260,81 +609,53 @@ func (gc *grpcClient) stopStreamInternal(cameraID string) error {
 	return nil
 }
 
-func (gc *grpcClient) GetStreamStatus(cameraID string) StreamStatus {
-	if !gc.IsConnected() {
-		return StreamStatusError
+func (gc *grpcClient) handleStatus(cameraID string) statusResult {
+	if gc.conn == nil {
+		return statusResult{status: StreamStatusError}
 	}
 
-	// First check local state
-	gc.mutex.RLock()
 	if stream, exists := gc.streams[cameraID]; exists {
-		status := stream.status
-		gc.mutex.RUnlock()
-		return status
+		return statusResult{
+			status:    stream.status,
+			dropped:   atomic.LoadInt64(&stream.droppedFrames),
+			hasStream: true,
+		}
 	}
-	gc.mutex.RUnlock()
 
-	// Query the C++ service
-	req := &pb.StatusRequest{
-		CameraId: cameraID,
-	}
+	req := &pb.StatusRequest{CameraId: cameraID}
 
-	resp, err := gc.client.GetStreamStatus(context.Background(), req)
+	callCtx, callCancel := context.WithTimeout(context.Background(), gc.opts.CallTimeout)
+	resp, err := gc.client.GetStreamStatus(callCtx, req)
+	callCancel()
 	if err != nil {
 		log.Printf("Failed to get stream status: %v", err)
-		return StreamStatusError
+		return statusResult{status: StreamStatusError}
 	}
 
-	// Convert C++ status to Go status
 	switch resp.Status {
 	case "active":
-		return StreamStatusActive
+		return statusResult{status: StreamStatusActive}
 	case "starting":
-		return StreamStatusStarting
+		return statusResult{status: StreamStatusStarting}
 	case "stopped":
-		return StreamStatusStopped
+		return statusResult{status: StreamStatusStopped}
 	default:
-		return StreamStatusError
+		return statusResult{status: StreamStatusError}
 	}
 }
 
-func (gc *grpcClient) IsConnected() bool {
-	gc.connectMutex.Lock()
-	defer gc.connectMutex.Unlock()
-
-	if !gc.connected || gc.conn == nil {
-		return false
-	}
-
-	// Check actual connection state
-	state := gc.conn.GetState()
-	switch state {
-	case connectivity.Ready, connectivity.Idle:
-		return true
-	case connectivity.Connecting:
-		return true // Optimistically consider connecting as connected
-	default:
-		gc.connected = false
-		return false
-	}
-}
-
-// HealthCheck performs a health check against the C++ service
-func (gc *grpcClient) HealthCheck() error {
-	if !gc.IsConnected() {
+func (gc *grpcClient) handleHealthCheck() error {
+	if gc.conn == nil {
 		return fmt.Errorf("not connected to vision service")
 	}
 
-	req := &pb.HealthRequest{}
-
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	resp, err := gc.client.GetHealth(ctx, req)
+	resp, err := gc.client.GetHealth(ctx, &pb.HealthRequest{})
 	if err != nil {
 		return fmt.Errorf("health check failed: %w", err)
 	}
-
 	if resp.Status != "healthy" {
 		return fmt.Errorf("vision service unhealthy: %s", resp.Message)
 	}
@@ -344,3 +665,227 @@ func (gc *grpcClient) HealthCheck() error {
 
 	return nil
 }
+
+// handleShutdown arrête tous les streams actifs (ce qui annule le contexte
+// de leurs goroutines streamFrames), annule gc.ctx (ce qui termine
+// watchConnState et healthMonitor), attend la sortie des streamFrames puis
+// ferme la connexion. Appelée en dernier par run(), qui termine sa
+// boucle juste après.
+func (gc *grpcClient) handleShutdown(ctx context.Context) error {
+	for cameraID := range gc.streams {
+		gc.stopStreamLocked(cameraID)
+	}
+
+	gc.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		gc.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return fmt.Errorf("gRPC client shutdown did not complete in time: %w", ctx.Err())
+	}
+
+	if gc.conn != nil {
+		return gc.conn.Close()
+	}
+	return nil
+}
+
+// streamFrames consomme le flux server-streaming StreamFrames du service
+// vision et pousse chaque pb.Frame converti sur stream.framesChan. Une
+// coupure transitoire (codes.Unavailable) déclenche une resouscription avec
+// since_timestamp_unix_nano positionné sur la dernière frame reçue, pour ne
+// pas la retransmettre ; tout autre code d'erreur (ou io.EOF) termine
+// définitivement le flux. Si framesChan est plein, la frame est comptée dans
+// stream.droppedFrames plutôt que de bloquer l'appelant (voir
+// DroppedFrameCount). Tourne indépendamment de run() : gc.client n'est lu
+// qu'une fois par itération via StreamFrames, jamais muté ici.
+func (gc *grpcClient) streamFrames(ctx context.Context, stream *grpcStream, baseReq *pb.StreamRequest) {
+	defer gc.wg.Done()
+	defer close(stream.framesChan)
+
+	var lastTimestampUnixNano int64
+	backoffDelay := gc.opts.InitialBackoff
+
+	for {
+		req := *baseReq
+		req.SinceTimestampUnixNano = lastTimestampUnixNano
+
+		frameStream, err := gc.client.StreamFrames(ctx, &req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("⚠️ Failed to open frame stream for camera %s: %v", stream.cameraID, err)
+			if !gc.waitBeforeResubscribe(ctx, &backoffDelay) {
+				return
+			}
+			continue
+		}
+
+		unavailable := false
+		for {
+			pbFrame, recvErr := frameStream.Recv()
+			if recvErr == io.EOF {
+				log.Printf("📹 Vision service closed frame stream for camera %s", stream.cameraID)
+				return
+			}
+			if recvErr != nil {
+				if status.Code(recvErr) == codes.Unavailable {
+					log.Printf("🔄 Frame stream unavailable for camera %s, resubscribing since %d: %v",
+						stream.cameraID, lastTimestampUnixNano, recvErr)
+					unavailable = true
+					break
+				}
+				log.Printf("❌ Frame stream error for camera %s: %v", stream.cameraID, recvErr)
+				return
+			}
+
+			frame := core.Frame{
+				CameraID:  pbFrame.CameraId,
+				Data:      pbFrame.Data,
+				Width:     int(pbFrame.Width),
+				Height:    int(pbFrame.Height),
+				Format:    pbFrame.Format,
+				Timestamp: time.Unix(0, pbFrame.TimestampUnixNano),
+				Size:      len(pbFrame.Data),
+			}
+			lastTimestampUnixNano = pbFrame.TimestampUnixNano
+			backoffDelay = gc.opts.InitialBackoff
+
+			select {
+			case stream.framesChan <- frame:
+			default:
+				atomic.AddInt64(&stream.droppedFrames, 1)
+			}
+		}
+
+		if !unavailable {
+			return
+		}
+		if !gc.waitBeforeResubscribe(ctx, &backoffDelay) {
+			return
+		}
+	}
+}
+
+// waitBeforeResubscribe attend backoffDelay (doublé à chaque appel, borné par
+// MaxBackoff) avant une nouvelle tentative de StreamFrames, et retourne false
+// si ctx s'annule pendant l'attente.
+func (gc *grpcClient) waitBeforeResubscribe(ctx context.Context, delay *time.Duration) bool {
+	select {
+	case <-time.After(*delay):
+	case <-ctx.Done():
+		return false
+	}
+
+	*delay *= 2
+	if *delay > gc.opts.MaxBackoff {
+		*delay = gc.opts.MaxBackoff
+	}
+	return true
+}
+
+func (gc *grpcClient) StartStream(ctx context.Context, cameraID string) (<-chan core.Frame, error) {
+	gc.closeMutex.RLock()
+	defer gc.closeMutex.RUnlock()
+	if gc.closed {
+		return nil, ErrGRPCClientClosed
+	}
+
+	reply := make(chan startStreamResult, 1)
+	gc.startStreamCh <- startStreamReq{ctx: ctx, cameraID: cameraID, reply: reply}
+	res := <-reply
+	return res.frames, res.err
+}
+
+func (gc *grpcClient) StopStream(cameraID string) error {
+	gc.closeMutex.RLock()
+	defer gc.closeMutex.RUnlock()
+	if gc.closed {
+		return ErrGRPCClientClosed
+	}
+
+	reply := make(chan error, 1)
+	gc.stopStreamCh <- stopStreamReq{cameraID: cameraID, reply: reply}
+	return <-reply
+}
+
+func (gc *grpcClient) GetStreamStatus(cameraID string) StreamStatus {
+	gc.closeMutex.RLock()
+	defer gc.closeMutex.RUnlock()
+	if gc.closed {
+		return StreamStatusStopped
+	}
+
+	reply := make(chan statusResult, 1)
+	gc.statusCh <- statusReq{cameraID: cameraID, reply: reply}
+	return (<-reply).status
+}
+
+// DroppedFrameCount retourne le nombre de frames perdues faute de place dans
+// framesChan pour cameraID, et false si aucun stream n'est actif pour cette
+// caméra. Implémente l'interface optionnelle FrameDropCounter (voir client.go).
+func (gc *grpcClient) DroppedFrameCount(cameraID string) (int64, bool) {
+	gc.closeMutex.RLock()
+	defer gc.closeMutex.RUnlock()
+	if gc.closed {
+		return 0, false
+	}
+
+	reply := make(chan statusResult, 1)
+	gc.statusCh <- statusReq{cameraID: cameraID, reply: reply}
+	res := <-reply
+	return res.dropped, res.hasStream
+}
+
+// IsConnected lit le flag connected maintenu par run() (dial() et
+// handleConnStateChanged), sans passer par un canal : un simple bool lu
+// souvent ne justifie pas une sérialisation supplémentaire.
+func (gc *grpcClient) IsConnected() bool {
+	return atomic.LoadInt32(&gc.connected) == 1
+}
+
+// IsHealthy lit le dernier résultat de healthMonitor, sans passer par un
+// canal comme IsConnected. Il vaut true dès que dial() a établi la connexion
+// avec HealthCheckInterval > 0, tant qu'aucun Check n'a encore été publié ;
+// il reste false avant toute connexion établie et quand la surveillance
+// périodique est désactivée.
+// Implémente l'interface optionnelle HealthReporter (voir client.go).
+func (gc *grpcClient) IsHealthy() bool {
+	return atomic.LoadInt32(&gc.visionHealthy) != 0
+}
+
+// Close arrête tous les streams actifs, la connexion et la goroutine run(),
+// en attendant que streamFrames se termine ou que ctx expire.
+func (gc *grpcClient) Close(ctx context.Context) error {
+	gc.closeMutex.Lock()
+	if gc.closed {
+		gc.closeMutex.Unlock()
+		return nil
+	}
+	gc.closed = true
+	gc.closeMutex.Unlock()
+
+	reply := make(chan error, 1)
+	gc.shutdownCh <- shutdownReq{ctx: ctx, reply: reply}
+	return <-reply
+}
+
+// HealthCheck performs a health check against the C++ service
+func (gc *grpcClient) HealthCheck() error {
+	gc.closeMutex.RLock()
+	defer gc.closeMutex.RUnlock()
+	if gc.closed {
+		return ErrGRPCClientClosed
+	}
+
+	reply := make(chan error, 1)
+	gc.healthCh <- healthReq{reply: reply}
+	return <-reply
+}
@@ -0,0 +1,113 @@
+// internal/vision/grpc_client_test.go
+package vision
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestGRPCClient construit un grpcClient avec ses canaux internes prêts,
+// sans passer par NewGRPCClient/dial() (qui ouvrirait une vraie connexion
+// gRPC) : même approche boîte-blanche que grpc_health_test.go pour tester
+// les méthodes publiques de grpcClient isolément.
+func newTestGRPCClient() *grpcClient {
+	return &grpcClient{
+		streams:       make(map[string]*grpcStream),
+		startStreamCh: make(chan startStreamReq),
+		stopStreamCh:  make(chan stopStreamReq),
+		statusCh:      make(chan statusReq),
+		healthCh:      make(chan healthReq),
+		shutdownCh:    make(chan shutdownReq),
+	}
+}
+
+// fakeRunLoop répond aux requêtes publiques comme le ferait run(), sans
+// dial() ni état de connexion réel, jusqu'à recevoir une shutdownReq.
+func fakeRunLoop(gc *grpcClient) {
+	for {
+		select {
+		case req := <-gc.startStreamCh:
+			req.reply <- startStreamResult{}
+		case req := <-gc.stopStreamCh:
+			req.reply <- nil
+		case req := <-gc.statusCh:
+			req.reply <- statusResult{}
+		case req := <-gc.healthCh:
+			req.reply <- nil
+		case req := <-gc.shutdownCh:
+			req.reply <- nil
+			return
+		}
+	}
+}
+
+func TestGRPCClient_PublicMethodsRejectCallsAfterClose(t *testing.T) {
+	gc := newTestGRPCClient()
+	go fakeRunLoop(gc)
+
+	if _, err := gc.StartStream(context.Background(), "cam1"); err != nil {
+		t.Fatalf("unexpected error before Close: %v", err)
+	}
+
+	if err := gc.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	// Close doit être idempotente : un second appel ne doit pas bloquer sur
+	// shutdownCh, que fakeRunLoop ne lit plus après son premier passage.
+	if err := gc.Close(context.Background()); err != nil {
+		t.Fatalf("second Close: %v", err)
+	}
+
+	if _, err := gc.StartStream(context.Background(), "cam1"); err != ErrGRPCClientClosed {
+		t.Fatalf("expected ErrGRPCClientClosed from StartStream after Close, got %v", err)
+	}
+	if err := gc.StopStream("cam1"); err != ErrGRPCClientClosed {
+		t.Fatalf("expected ErrGRPCClientClosed from StopStream after Close, got %v", err)
+	}
+	if status := gc.GetStreamStatus("cam1"); status != StreamStatusStopped {
+		t.Fatalf("expected StreamStatusStopped from GetStreamStatus after Close, got %v", status)
+	}
+	if _, ok := gc.DroppedFrameCount("cam1"); ok {
+		t.Fatal("expected DroppedFrameCount to report no stream after Close")
+	}
+	if err := gc.HealthCheck(); err != ErrGRPCClientClosed {
+		t.Fatalf("expected ErrGRPCClientClosed from HealthCheck after Close, got %v", err)
+	}
+}
+
+// TestGRPCClient_CloseRacesWithInFlightCalls exerce le closeMutex sous -race :
+// une goroutine martèle les méthodes publiques pendant qu'une autre appelle
+// Close, pour vérifier qu'aucun appel ne bloque indéfiniment sur un canal que
+// fakeRunLoop a cessé de lire et qu'aucune race n'apparaît sur gc.closed.
+func TestGRPCClient_CloseRacesWithInFlightCalls(t *testing.T) {
+	gc := newTestGRPCClient()
+	go fakeRunLoop(gc)
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			_, _ = gc.StartStream(context.Background(), "cam1")
+			_ = gc.StopStream("cam1")
+			_ = gc.GetStreamStatus("cam1")
+			_, _ = gc.DroppedFrameCount("cam1")
+			_ = gc.HealthCheck()
+		}
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	if err := gc.Close(context.Background()); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	close(stop)
+	wg.Wait()
+}
@@ -0,0 +1,101 @@
+// internal/vision/grpc_health_test.go
+package vision
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newHealthTestServer démarre un serveur gRPC en mémoire (bufconn) exposant
+// uniquement le service de santé standard, fourni par google.golang.org/grpc/health
+// plutôt que réimplémenté à la main. status pilote la réponse initiale ; le
+// *health.Server retourné permet de la faire varier pendant le test (voir
+// SetServingStatus).
+func newHealthTestServer(t *testing.T, status grpc_health_v1.HealthCheckResponse_ServingStatus) (*bufconn.Listener, *healthpb.Server) {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	healthSrv := healthpb.NewServer()
+	healthSrv.SetServingStatus("", status)
+	grpc_health_v1.RegisterHealthServer(server, healthSrv)
+
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	t.Cleanup(server.Stop)
+
+	return lis, healthSrv
+}
+
+func dialBufconn(t *testing.T, lis *bufconn.Listener) *grpc.ClientConn {
+	t.Helper()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial bufconn: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestGRPCClient_CheckHealthWithRetry_Serving(t *testing.T) {
+	lis, _ := newHealthTestServer(t, grpc_health_v1.HealthCheckResponse_SERVING)
+	conn := dialBufconn(t, lis)
+
+	gc := &grpcClient{opts: GRPCClientOptions{
+		CallTimeout:      time.Second,
+		InitialBackoff:   10 * time.Millisecond,
+		MaxBackoff:       50 * time.Millisecond,
+		MaxRetryAttempts: 2,
+	}}
+
+	if !gc.checkHealthWithRetry(context.Background(), grpc_health_v1.NewHealthClient(conn)) {
+		t.Fatal("expected healthy result for SERVING status")
+	}
+}
+
+func TestGRPCClient_CheckHealthWithRetry_NotServingExhaustsRetries(t *testing.T) {
+	lis, _ := newHealthTestServer(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	conn := dialBufconn(t, lis)
+
+	gc := &grpcClient{opts: GRPCClientOptions{
+		CallTimeout:      time.Second,
+		InitialBackoff:   5 * time.Millisecond,
+		MaxBackoff:       20 * time.Millisecond,
+		MaxRetryAttempts: 2,
+	}}
+
+	if gc.checkHealthWithRetry(context.Background(), grpc_health_v1.NewHealthClient(conn)) {
+		t.Fatal("expected unhealthy result for NOT_SERVING status")
+	}
+}
+
+func TestGRPCClient_HandleHealthChanged_OnlyNotifiesOnTransition(t *testing.T) {
+	var transitions []bool
+	gc := &grpcClient{opts: GRPCClientOptions{
+		OnHealthChange: func(healthy bool) { transitions = append(transitions, healthy) },
+	}}
+
+	gc.handleHealthChanged(true)
+	gc.handleHealthChanged(true)
+	gc.handleHealthChanged(false)
+	gc.handleHealthChanged(false)
+
+	if want := []bool{true, false}; len(transitions) != len(want) || transitions[0] != want[0] || transitions[1] != want[1] {
+		t.Fatalf("expected transitions %v, got %v", want, transitions)
+	}
+}
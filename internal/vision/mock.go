@@ -1,20 +1,33 @@
 package vision
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"surveillance-core/internal/core"
+	"surveillance-core/internal/logging"
+	"surveillance-core/internal/metrics"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// Client abstrait la capture vidéo d'une caméra, quel que soit le backend
+// (mock, RTSP, ONVIF, gRPC). ctx dans StartStream borne la durée de vie du
+// flux : son annulation (arrêt de l'application, voir Close) doit provoquer
+// la fermeture de framesChan par le producteur lui-même, une fois les
+// frames en attente écoulées, plutôt qu'une fermeture brutale pilotée par
+// StopStream.
 type Client interface {
-	StartStream(cameraID string) (<-chan core.Frame, error)
+	StartStream(ctx context.Context, cameraID string) (<-chan core.Frame, error)
 	StopStream(cameraID string) error
 	GetStreamStatus(cameraID string) StreamStatus
 	IsConnected() bool
+	// Close arrête tous les flux actifs et attend que leurs goroutines
+	// productrices se terminent, ou que ctx expire. À appeler une seule fois,
+	// après server.Shutdown, voir cmd/server/main.go.
+	Close(ctx context.Context) error
 }
 
 type StreamStatus string
@@ -29,13 +42,14 @@ const (
 type mockClient struct {
 	streams map[string]*mockStream
 	mutex   sync.RWMutex
+	wg      sync.WaitGroup
 }
 
 type mockStream struct {
 	cameraID   string
 	status     StreamStatus
 	framesChan chan core.Frame
-	stopChan   chan bool
+	cancel     context.CancelFunc
 	ticker     *time.Ticker
 }
 
@@ -45,7 +59,7 @@ func NewMockClient() Client {
 	}
 }
 
-func (mc *mockClient) StartStream(cameraID string) (<-chan core.Frame, error) {
+func (mc *mockClient) StartStream(ctx context.Context, cameraID string) (<-chan core.Frame, error) {
 	mc.mutex.Lock()
 	defer mc.mutex.Unlock()
 
@@ -58,22 +72,27 @@ func (mc *mockClient) StartStream(cameraID string) (<-chan core.Frame, error) {
 		mc.stopStreamInternal(cameraID)
 	}
 
-	// Créer nouveau stream
+	// Créer nouveau stream, dont le cycle de vie est lié à ctx : son
+	// annulation (StopStream ou Close) arrête generateFrames, qui ferme
+	// lui-même framesChan.
+	streamCtx, cancel := context.WithCancel(ctx)
 	stream := &mockStream{
 		cameraID:   cameraID,
 		status:     StreamStatusStarting,
 		framesChan: make(chan core.Frame, 10),
-		stopChan:   make(chan bool),
+		cancel:     cancel,
 		ticker:     time.NewTicker(time.Second / 15), // 15 FPS
 	}
 
 	mc.streams[cameraID] = stream
 
 	// Démarrer le générateur de frames en goroutine
-	go mc.generateFrames(stream)
+	mc.wg.Add(1)
+	go mc.generateFrames(streamCtx, stream)
 
 	stream.status = StreamStatusActive
-	fmt.Printf("Stream démarré pour caméra: %s\n", cameraID)
+	metrics.StreamStatus.WithLabelValues(cameraID).Set(metrics.StreamStatusValue(string(stream.status)))
+	logging.Logger.WithFields(logging.CameraFields(cameraID)).WithField("stream_status", stream.status).Info("Stream démarré")
 
 	return stream.framesChan, nil
 }
@@ -85,23 +104,46 @@ func (mc *mockClient) StopStream(cameraID string) error {
 	return mc.stopStreamInternal(cameraID)
 }
 
+// stopStreamInternal annule le contexte du stream : c'est generateFrames,
+// seule propriétaire de framesChan, qui se charge de le fermer en sortant.
 func (mc *mockClient) stopStreamInternal(cameraID string) error {
 	stream, exists := mc.streams[cameraID]
 	if !exists {
 		return fmt.Errorf("stream non trouvé pour caméra: %s", cameraID)
 	}
 
-	// Arrêter le générateur
-	stream.ticker.Stop()
-	close(stream.stopChan)
-	close(stream.framesChan)
-
+	stream.cancel()
 	delete(mc.streams, cameraID)
-	fmt.Printf("Stream arrêté pour caméra: %s\n", cameraID)
+	metrics.StreamStatus.WithLabelValues(cameraID).Set(metrics.StreamStatusValue(string(StreamStatusStopped)))
+	logging.Logger.WithFields(logging.CameraFields(cameraID)).WithField("stream_status", StreamStatusStopped).Info("Stream arrêté")
 
 	return nil
 }
 
+// Close annule tous les streams actifs et attend que leurs générateurs de
+// frames se soient terminés (ticker arrêté, framesChan fermé), ou que ctx
+// expire — typiquement borné par config.Server.ShutdownTimeout.
+func (mc *mockClient) Close(ctx context.Context) error {
+	mc.mutex.Lock()
+	for cameraID := range mc.streams {
+		mc.stopStreamInternal(cameraID)
+	}
+	mc.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		mc.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("arrêt des flux mock non terminé avant expiration du délai: %w", ctx.Err())
+	}
+}
+
 func (mc *mockClient) GetStreamStatus(cameraID string) StreamStatus {
 	mc.mutex.RLock()
 	defer mc.mutex.RUnlock()
@@ -116,12 +158,16 @@ func (mc *mockClient) IsConnected() bool {
 	return true // Mock toujours connecté
 }
 
-func (mc *mockClient) generateFrames(stream *mockStream) {
+func (mc *mockClient) generateFrames(ctx context.Context, stream *mockStream) {
+	defer mc.wg.Done()
+	defer stream.ticker.Stop()
+	defer close(stream.framesChan)
+
 	detectionCounter := 0
 
 	for {
 		select {
-		case <-stream.stopChan:
+		case <-ctx.Done():
 			return
 
 		case <-stream.ticker.C:
@@ -140,6 +186,7 @@ func (mc *mockClient) generateFrames(stream *mockStream) {
 			// Envoyer frame (non-bloquant)
 			select {
 			case stream.framesChan <- frame:
+				metrics.FramesReceivedTotal.WithLabelValues(stream.cameraID).Inc()
 				// Simuler détections occasionnelles
 				detectionCounter++
 				if detectionCounter%45 == 0 { // Toutes les 3 secondes à 15fps
@@ -147,6 +194,7 @@ func (mc *mockClient) generateFrames(stream *mockStream) {
 				}
 			default:
 				// Canal plein, ignorer cette frame
+				metrics.FramesDroppedTotal.WithLabelValues(stream.cameraID).Inc()
 			}
 		}
 	}
@@ -194,8 +242,10 @@ func (mc *mockClient) simulateDetection(cameraID string) {
 		},
 	}
 
-	fmt.Printf("🔍 Détection simulée: %s sur %s (confiance: %.2f)\n",
-		detectionType, cameraID, confidence)
+	logging.Logger.WithFields(logging.CameraFields(cameraID)).WithFields(map[string]interface{}{
+		"detection_type": detectionType,
+		"confidence":     confidence,
+	}).Info("Détection simulée")
 
 	// Dans un vrai système, ceci irait vers l'EventProcessor
 	// Pour le mock, on utilise la variable pour éviter l'erreur de compilation
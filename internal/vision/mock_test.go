@@ -2,16 +2,19 @@
 package vision
 
 import (
+	"context"
 	"surveillance-core/internal/core"
 	"testing"
 	"time"
+
+	"go.uber.org/goleak"
 )
 
 func TestMockClient_StartStream(t *testing.T) {
 	client := NewMockClient()
 
 	// Test démarrage stream
-	framesChan, err := client.StartStream("test_cam")
+	framesChan, err := client.StartStream(context.Background(), "test_cam")
 	if err != nil {
 		t.Errorf("Unexpected error starting stream: %v", err)
 	}
@@ -33,13 +36,13 @@ func TestMockClient_StartStream_AlreadyRunning(t *testing.T) {
 	client := NewMockClient()
 
 	// Démarrer le stream
-	framesChan1, err := client.StartStream("test_cam")
+	framesChan1, err := client.StartStream(context.Background(), "test_cam")
 	if err != nil {
 		t.Fatalf("Unexpected error starting first stream: %v", err)
 	}
 
 	// Démarrer le même stream à nouveau
-	framesChan2, err := client.StartStream("test_cam")
+	framesChan2, err := client.StartStream(context.Background(), "test_cam")
 	if err != nil {
 		t.Errorf("Unexpected error starting second stream: %v", err)
 	}
@@ -57,7 +60,7 @@ func TestMockClient_StopStream(t *testing.T) {
 	client := NewMockClient()
 
 	// Démarrer puis arrêter
-	_, err := client.StartStream("test_cam")
+	_, err := client.StartStream(context.Background(), "test_cam")
 	if err != nil {
 		t.Fatalf("Unexpected error starting stream: %v", err)
 	}
@@ -94,7 +97,7 @@ func TestMockClient_GetStreamStatus(t *testing.T) {
 	}
 
 	// Après démarrage
-	client.StartStream("test_cam")
+	client.StartStream(context.Background(), "test_cam")
 	status = client.GetStreamStatus("test_cam")
 	if status != StreamStatusActive {
 		t.Errorf("Expected active status %s, got %s", StreamStatusActive, status)
@@ -116,7 +119,7 @@ func TestMockClient_IsConnected(t *testing.T) {
 func TestMockClient_FrameGeneration(t *testing.T) {
 	client := NewMockClient()
 
-	framesChan, err := client.StartStream("test_cam")
+	framesChan, err := client.StartStream(context.Background(), "test_cam")
 	if err != nil {
 		t.Fatalf("Unexpected error starting stream: %v", err)
 	}
@@ -172,7 +175,7 @@ func TestMockClient_MultipleStreams(t *testing.T) {
 	channels := make(map[string]<-chan core.Frame)
 
 	for _, camID := range cameras {
-		framesChan, err := client.StartStream(camID)
+		framesChan, err := client.StartStream(context.Background(), camID)
 		if err != nil {
 			t.Errorf("Error starting stream for %s: %v", camID, err)
 		}
@@ -235,7 +238,7 @@ func TestMockClient_MultipleStreams(t *testing.T) {
 func TestMockClient_FrameDataVariation(t *testing.T) {
 	client := NewMockClient()
 
-	framesChan, err := client.StartStream("test_cam")
+	framesChan, err := client.StartStream(context.Background(), "test_cam")
 	if err != nil {
 		t.Fatalf("Unexpected error starting stream: %v", err)
 	}
@@ -277,7 +280,7 @@ func TestMockClient_FrameDataVariation(t *testing.T) {
 func BenchmarkMockClient_FrameGeneration(b *testing.B) {
 	client := NewMockClient()
 
-	framesChan, err := client.StartStream("bench_cam")
+	framesChan, err := client.StartStream(context.Background(), "bench_cam")
 	if err != nil {
 		b.Fatalf("Error starting stream: %v", err)
 	}
@@ -302,7 +305,7 @@ func BenchmarkMockClient_FrameGeneration(b *testing.B) {
 func TestMockClient_ChannelCleanup(t *testing.T) {
 	client := NewMockClient()
 
-	framesChan, err := client.StartStream("test_cam")
+	framesChan, err := client.StartStream(context.Background(), "test_cam")
 	if err != nil {
 		t.Fatalf("Error starting stream: %v", err)
 	}
@@ -324,3 +327,27 @@ func TestMockClient_ChannelCleanup(t *testing.T) {
 		t.Errorf("Channel should be closed immediately after stopping")
 	}
 }
+
+// TestMockClient_CloseNoGoroutineLeak vérifie que Close arrête bien toutes
+// les goroutines generateFrames, y compris celles de streams jamais arrêtés
+// explicitement via StopStream (cas du graceful shutdown, voir
+// cmd/server/main.go). À lancer avec -race.
+func TestMockClient_CloseNoGoroutineLeak(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	client := NewMockClient()
+
+	cameras := []string{"cam_001", "cam_002", "cam_003"}
+	for _, camID := range cameras {
+		if _, err := client.StartStream(context.Background(), camID); err != nil {
+			t.Fatalf("Error starting stream for %s: %v", camID, err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := client.Close(ctx); err != nil {
+		t.Fatalf("Unexpected error closing client: %v", err)
+	}
+}
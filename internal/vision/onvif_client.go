@@ -0,0 +1,129 @@
+// internal/vision/onvif_client.go
+package vision
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"surveillance-core/internal/core"
+)
+
+// onvifDiscoveryTimeout borne la fenêtre d'écoute des réponses WS-Discovery
+// quand une URL onvif:// ne précise pas d'hôte (découverte automatique).
+const onvifDiscoveryTimeout = 3 * time.Second
+
+// onvifClient implémente Client pour les caméras exposant un service ONVIF :
+// il résout l'URI RTSP réelle du flux via le service Media (GetProfiles +
+// GetStreamUri), au besoin après une découverte WS-Discovery, puis délègue
+// la capture elle-même à un rtspClient interne pour réutiliser sa
+// reconnexion à backoff exponentiel et son repli FFmpeg (voir
+// rtsp_client.go).
+type onvifClient struct {
+	rtsp Client
+
+	mutex    sync.RWMutex
+	resolved map[string]string // cameraID -> URI RTSP résolue
+}
+
+// NewONVIFClient crée un client ONVIF qui capture via gortsplib/FFmpeg une
+// fois l'URI de flux résolue.
+func NewONVIFClient(rtspConfig RTSPClientConfig) Client {
+	return &onvifClient{
+		rtsp:     NewRTSPClient(rtspConfig),
+		resolved: make(map[string]string),
+	}
+}
+
+func (oc *onvifClient) StartStream(ctx context.Context, cameraID string) (<-chan core.Frame, error) {
+	oc.mutex.RLock()
+	uri, ok := oc.resolved[cameraID]
+	oc.mutex.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("URI RTSP non résolue pour la caméra ONVIF %s: utiliser StartStreamWithURL", cameraID)
+	}
+	return oc.rtsp.(URLAwareClient).StartStreamWithURL(ctx, cameraID, uri)
+}
+
+// StartStreamWithURL résout onvifURL (onvif://[user:pass@]host:port) en une
+// URI RTSP via le service Media ONVIF, puis démarre la capture. Si
+// onvifURL ne précise pas d'hôte (ex. "onvif://"), une découverte
+// WS-Discovery est lancée pour trouver le premier périphérique du réseau.
+func (oc *onvifClient) StartStreamWithURL(ctx context.Context, cameraID, onvifURL string) (<-chan core.Frame, error) {
+	return oc.StartStreamWithOptions(ctx, cameraID, onvifURL, StreamOptions{})
+}
+
+// StartStreamWithOptions résout onvifURL comme StartStreamWithURL, puis
+// transmet opts (transport RTP, codec préféré) au rtspClient interne pour la
+// capture du flux résolu.
+func (oc *onvifClient) StartStreamWithOptions(ctx context.Context, cameraID, onvifURL string, opts StreamOptions) (<-chan core.Frame, error) {
+	mediaServiceAddr, err := onvifMediaServiceAddr(onvifURL)
+	if err != nil {
+		return nil, fmt.Errorf("résolution adresse ONVIF pour %s: %w", cameraID, err)
+	}
+
+	streamURI, err := onvifResolveStreamURI(mediaServiceAddr)
+	if err != nil {
+		return nil, fmt.Errorf("résolution flux ONVIF pour %s: %w", cameraID, err)
+	}
+
+	oc.mutex.Lock()
+	oc.resolved[cameraID] = streamURI
+	oc.mutex.Unlock()
+
+	return oc.rtsp.(TransportAwareClient).StartStreamWithOptions(ctx, cameraID, streamURI, opts)
+}
+
+func (oc *onvifClient) StopStream(cameraID string) error {
+	oc.mutex.Lock()
+	delete(oc.resolved, cameraID)
+	oc.mutex.Unlock()
+
+	return oc.rtsp.StopStream(cameraID)
+}
+
+func (oc *onvifClient) GetStreamStatus(cameraID string) StreamStatus {
+	return oc.rtsp.GetStreamStatus(cameraID)
+}
+
+func (oc *onvifClient) IsConnected() bool {
+	return oc.rtsp.IsConnected()
+}
+
+// Close relaie au rtspClient interne, qui porte le cycle de vie effectif du
+// flux capturé.
+func (oc *onvifClient) Close(ctx context.Context) error {
+	return oc.rtsp.Close(ctx)
+}
+
+// SetAlertSink relaie au rtspClient sous-jacent, qui émet déjà les alertes
+// système de reconnexion (voir rtsp_client.go).
+func (oc *onvifClient) SetAlertSink(sink func(core.Alert)) {
+	if sinkable, ok := oc.rtsp.(interface{ SetAlertSink(func(core.Alert)) }); ok {
+		sinkable.SetAlertSink(sink)
+	}
+}
+
+// onvifMediaServiceAddr convertit une URL onvif://[user:pass@]host:port en
+// l'adresse HTTP du service Media ONVIF (chemin conventionnel
+// /onvif/media_service). Si host est vide, une découverte WS-Discovery
+// résout la première caméra trouvée sur le réseau local.
+func onvifMediaServiceAddr(onvifURL string) (string, error) {
+	u, err := url.Parse(onvifURL)
+	if err != nil {
+		return "", fmt.Errorf("URL ONVIF invalide: %w", err)
+	}
+
+	if u.Host == "" {
+		xaddrs, err := discoverONVIFDevices(onvifDiscoveryTimeout)
+		if err != nil {
+			return "", err
+		}
+		return xaddrs[0], nil
+	}
+
+	return fmt.Sprintf("http://%s/onvif/media_service", u.Host), nil
+}
@@ -0,0 +1,87 @@
+package vision
+
+import (
+	"sync"
+
+	"surveillance-core/internal/core"
+)
+
+// PacketQueue fan-out un flux de frames décodées vers plusieurs abonnés
+// (détecteur de mouvement, broadcaster WebRTC, enregistreur, ...) sans
+// re-décoder le flux pour chacun d'eux.
+type PacketQueue struct {
+	mutex       sync.RWMutex
+	subscribers map[int]chan core.Frame
+	nextID      int
+	bufferSize  int
+}
+
+// NewPacketQueue crée une file de distribution avec une taille de buffer
+// par abonné donnée (les abonnés lents perdent des frames plutôt que de
+// bloquer le producteur).
+func NewPacketQueue(bufferSize int) *PacketQueue {
+	if bufferSize <= 0 {
+		bufferSize = 10
+	}
+	return &PacketQueue{
+		subscribers: make(map[int]chan core.Frame),
+		bufferSize:  bufferSize,
+	}
+}
+
+// Subscribe enregistre un nouveau consommateur et retourne son channel de
+// lecture ainsi qu'une fonction de désabonnement à appeler une fois terminé.
+func (pq *PacketQueue) Subscribe() (<-chan core.Frame, func()) {
+	pq.mutex.Lock()
+	defer pq.mutex.Unlock()
+
+	id := pq.nextID
+	pq.nextID++
+
+	ch := make(chan core.Frame, pq.bufferSize)
+	pq.subscribers[id] = ch
+
+	unsubscribe := func() {
+		pq.mutex.Lock()
+		defer pq.mutex.Unlock()
+		if existing, ok := pq.subscribers[id]; ok {
+			delete(pq.subscribers, id)
+			close(existing)
+		}
+	}
+
+	return ch, unsubscribe
+}
+
+// Publish distribue une frame à tous les abonnés actuels. Un abonné dont le
+// buffer est plein perd simplement la frame (non-bloquant pour le reste du
+// pipeline).
+func (pq *PacketQueue) Publish(frame core.Frame) {
+	pq.mutex.RLock()
+	defer pq.mutex.RUnlock()
+
+	for _, ch := range pq.subscribers {
+		select {
+		case ch <- frame:
+		default:
+		}
+	}
+}
+
+// SubscriberCount retourne le nombre d'abonnés actifs.
+func (pq *PacketQueue) SubscriberCount() int {
+	pq.mutex.RLock()
+	defer pq.mutex.RUnlock()
+	return len(pq.subscribers)
+}
+
+// Close ferme tous les channels abonnés.
+func (pq *PacketQueue) Close() {
+	pq.mutex.Lock()
+	defer pq.mutex.Unlock()
+
+	for id, ch := range pq.subscribers {
+		close(ch)
+		delete(pq.subscribers, id)
+	}
+}
@@ -0,0 +1,136 @@
+package vision
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// remoteTokenClaims est le payload signé porté par un RemoteToken : il lie le
+// jeton à une caméra précise et à une expiration, pour qu'un service vision
+// distant sache quelle caméra l'appelant est autorisé à streamer et pendant
+// combien de temps (voir CameraConfig.RemoteURL).
+type remoteTokenClaims struct {
+	CameraID string `json:"camera_id"`
+	Exp      int64  `json:"exp"`
+}
+
+// SignRemoteToken produit un jeton HS256 "<payload>.<signature>" (base64url
+// sans padding) liant cameraID à son expiration, pour fédérer une caméra vers
+// un service vision distant sans exposer le port gRPC brut de ce service.
+// secret est le secret partagé configuré via Config.Security.JWTSecret.
+func SignRemoteToken(secret, cameraID string, ttl time.Duration) (string, error) {
+	claims := remoteTokenClaims{CameraID: cameraID, Exp: time.Now().Add(ttl).Unix()}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("encode remote token claims: %w", err)
+	}
+
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+	return encodedPayload + "." + signHS256(secret, encodedPayload), nil
+}
+
+// VerifyRemoteToken vérifie la signature et l'expiration d'un jeton produit
+// par SignRemoteToken, et retourne la caméra qu'il autorise.
+func VerifyRemoteToken(secret, token string) (cameraID string, err error) {
+	parts := strings.SplitN(token, ".", 2)
+	if len(parts) != 2 {
+		return "", fmt.Errorf("malformed remote token")
+	}
+	encodedPayload, sig := parts[0], parts[1]
+
+	if !hmac.Equal([]byte(sig), []byte(signHS256(secret, encodedPayload))) {
+		return "", fmt.Errorf("invalid remote token signature")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return "", fmt.Errorf("decode remote token payload: %w", err)
+	}
+
+	var claims remoteTokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", fmt.Errorf("decode remote token claims: %w", err)
+	}
+	if time.Now().Unix() > claims.Exp {
+		return "", fmt.Errorf("remote token expired")
+	}
+
+	return claims.CameraID, nil
+}
+
+// RemoteTokenExpiry décode l'expiration portée par un RemoteToken sans en
+// vérifier la signature, pour qu'un appelant qui a mis ce jeton en cache
+// (voir api.Handler.visionClientFor) sache quand le recréer, sans dupliquer
+// la vérification HS256 faite côté service vision distant par
+// VerifyRemoteToken.
+func RemoteTokenExpiry(token string) (time.Time, error) {
+	parts := strings.SplitN(token, ".", 2)
+	if len(parts) != 2 {
+		return time.Time{}, fmt.Errorf("malformed remote token")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("decode remote token payload: %w", err)
+	}
+
+	var claims remoteTokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, fmt.Errorf("decode remote token claims: %w", err)
+	}
+
+	return time.Unix(claims.Exp, 0), nil
+}
+
+func signHS256(secret, data string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(data))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// remoteTokenCredentials implémente credentials.PerRPCCredentials en
+// attachant un RemoteToken signé comme métadonnée gRPC "authorization" à
+// chaque appel, pour qu'un service vision distant puisse vérifier quelle
+// caméra l'appelant est autorisé à streamer (voir VerifyRemoteToken côté
+// serveur).
+type remoteTokenCredentials struct {
+	token string
+	// requireTransportSecurity reflète CameraConfig.RemoteTLS.Enabled au
+	// moment de la construction (voir NewRemoteGRPCClient) : si le canal a
+	// été configuré en clair, on ne ment pas à grpc-go en prétendant exiger
+	// un transport sécurisé pour autant ; si TLS est configuré, grpc-go
+	// refusera d'envoyer ce jeton si le canal venait à retomber en clair.
+	requireTransportSecurity bool
+}
+
+// NewRemoteTokenCredentials construit les PerRPCCredentials à passer via
+// GRPCClientOptions.PerRPCCredentials pour un backend vision distant (voir
+// NewRemoteGRPCClient). requireTransportSecurity doit valoir true dès que le
+// canal gRPC est effectivement chiffré (CameraConfig.RemoteTLS.Enabled),
+// pour que grpc-go refuse d'envoyer ce jeton bearer sur un transport en
+// clair.
+func NewRemoteTokenCredentials(token string, requireTransportSecurity bool) credentials.PerRPCCredentials {
+	return remoteTokenCredentials{token: token, requireTransportSecurity: requireTransportSecurity}
+}
+
+func (c remoteTokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + c.token}, nil
+}
+
+// RequireTransportSecurity reflète l'état de RemoteTLS.Enabled fourni à la
+// construction (voir NewRemoteTokenCredentials) plutôt qu'une valeur
+// hardcodée : un déploiement qui active RemoteTLS obtient la garantie de
+// grpc-go qu'aucun appel ne part en clair avec ce jeton ; un déploiement
+// intra-cluster qui laisse RemoteTLS désactivé conserve le comportement
+// existant.
+func (c remoteTokenCredentials) RequireTransportSecurity() bool {
+	return c.requireTransportSecurity
+}
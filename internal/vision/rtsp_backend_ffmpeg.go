@@ -0,0 +1,118 @@
+package vision
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	"surveillance-core/internal/core"
+)
+
+// ffmpegBackend est le backend de repli utilisé quand gortsplib échoue à
+// négocier le flux (par ex. caméras RTMP-only ou dialectes RTSP non
+// standards). Il délègue le décodage à un sous-processus FFmpeg (lui-même
+// basé sur libavformat/Joy4-équivalent) qui réencode le flux en MJPEG sur
+// stdout, un frame JPEG à la fois.
+type ffmpegBackend struct{}
+
+func newFFmpegBackend() rtspBackend {
+	return &ffmpegBackend{}
+}
+
+func (b *ffmpegBackend) Codec() StreamCodec {
+	return StreamCodecH264
+}
+
+func (b *ffmpegBackend) Open(ctx context.Context, cameraURL string, opts StreamOptions, onFrame func(core.Frame)) error {
+	transport := "tcp"
+	if strings.EqualFold(opts.Transport, "udp") {
+		transport = "udp"
+	}
+
+	cmd := exec.CommandContext(ctx, "ffmpeg",
+		"-rtsp_transport", transport,
+		"-i", cameraURL,
+		"-f", "mjpeg",
+		"-q:v", "5",
+		"pipe:1",
+	)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("impossible d'ouvrir stdout ffmpeg: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("impossible de démarrer ffmpeg: %w", err)
+	}
+
+	reader := bufio.NewReader(stdout)
+	readErr := make(chan error, 1)
+
+	go func() {
+		readErr <- readMJPEGFrames(reader, onFrame)
+	}()
+
+	select {
+	case <-ctx.Done():
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return nil
+	case err := <-readErr:
+		_ = cmd.Wait()
+		return err
+	}
+}
+
+// readMJPEGFrames découpe un flux MJPEG brut (markers SOI/EOI JFIF) en frames
+// JPEG individuelles et les remonte via onFrame.
+func readMJPEGFrames(r *bufio.Reader, onFrame func(core.Frame)) error {
+	const (
+		markerSOI = 0xD8
+		markerEOI = 0xD9
+		markerTag = 0xFF
+	)
+
+	var buf []byte
+	inFrame := false
+
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("lecture flux MJPEG: %w", err)
+		}
+
+		if !inFrame {
+			if b == markerTag {
+				next, err := r.ReadByte()
+				if err != nil {
+					return nil
+				}
+				if next == markerSOI {
+					inFrame = true
+					buf = []byte{markerTag, markerSOI}
+				}
+			}
+			continue
+		}
+
+		buf = append(buf, b)
+		if len(buf) >= 2 && buf[len(buf)-2] == markerTag && buf[len(buf)-1] == markerEOI {
+			onFrame(core.Frame{
+				Data:      buf,
+				Format:    "jpeg",
+				Timestamp: time.Now(),
+				Size:      len(buf),
+			})
+			buf = nil
+			inFrame = false
+		}
+	}
+}
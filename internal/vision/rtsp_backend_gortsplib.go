@@ -0,0 +1,130 @@
+package vision
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"surveillance-core/internal/core"
+
+	"github.com/bluenviron/gortsplib/v4"
+	"github.com/bluenviron/gortsplib/v4/pkg/base"
+	"github.com/bluenviron/gortsplib/v4/pkg/description"
+	"github.com/bluenviron/gortsplib/v4/pkg/format"
+	"github.com/pion/rtp"
+)
+
+// gortsplibBackend est le backend RTSP principal, basé sur la bibliothèque
+// Go pure gortsplib. Il négocie une piste H.264/H.265 et remonte la charge
+// utile de chaque paquet RTP de cette piste comme une core.Frame horodatée à
+// la réception : les paquets ne sont ni réassemblés en unités d'accès ni
+// décodés, et les PTS/DTS du flux ne sont pas repris.
+type gortsplibBackend struct {
+	codec StreamCodec
+}
+
+func newGortsplibBackend() rtspBackend {
+	return &gortsplibBackend{}
+}
+
+func (b *gortsplibBackend) Codec() StreamCodec {
+	if b.codec == "" {
+		return StreamCodecH264
+	}
+	return b.codec
+}
+
+func (b *gortsplibBackend) Open(ctx context.Context, cameraURL string, opts StreamOptions, onFrame func(core.Frame)) error {
+	client := &gortsplib.Client{}
+
+	if strings.EqualFold(opts.Transport, "udp") {
+		transport := gortsplib.TransportUDP
+		client.Transport = &transport
+	} else {
+		transport := gortsplib.TransportTCP
+		client.Transport = &transport
+	}
+
+	u, err := base.ParseURL(cameraURL)
+	if err != nil {
+		return fmt.Errorf("URL caméra invalide: %w", err)
+	}
+
+	if err := client.Start(u.Scheme, u.Host); err != nil {
+		return fmt.Errorf("connexion RTSP échouée: %w", err)
+	}
+	defer client.Close()
+
+	desc, _, err := client.Describe(u)
+	if err != nil {
+		return fmt.Errorf("DESCRIBE échoué: %w", err)
+	}
+
+	media, codec, err := findVideoMedia(desc, opts.PreferredCodec)
+	if err != nil {
+		return err
+	}
+	b.codec = codec
+
+	if err := client.SetupAll(desc.BaseURL, desc.Medias); err != nil {
+		return fmt.Errorf("SETUP échoué: %w", err)
+	}
+
+	client.OnPacketRTP(media, media.Formats[0], func(pkt *rtp.Packet) {
+		onFrame(core.Frame{
+			CameraID:  "",
+			Data:      pkt.Payload,
+			Format:    string(b.codec),
+			Timestamp: time.Now(),
+			Size:      len(pkt.Payload),
+		})
+	})
+
+	if _, err := client.Play(nil); err != nil {
+		return fmt.Errorf("PLAY échoué: %w", err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-client.Wait():
+		return err
+	}
+}
+
+// findVideoMedia localise la piste vidéo H.264/H.265 de la description SDP
+// et retourne le codec négocié. Si preferred est non vide et offert par la
+// caméra, il est choisi en priorité ; sinon la première piste supportée
+// trouvée est utilisée.
+func findVideoMedia(desc *description.Session, preferred StreamCodec) (*description.Media, StreamCodec, error) {
+	var fallbackMedia *description.Media
+	var fallbackCodec StreamCodec
+
+	for _, media := range desc.Medias {
+		for _, f := range media.Formats {
+			var codec StreamCodec
+			switch f.(type) {
+			case *format.H264:
+				codec = StreamCodecH264
+			case *format.H265:
+				codec = StreamCodecH265
+			default:
+				continue
+			}
+
+			if preferred != "" && codec == preferred {
+				return media, codec, nil
+			}
+			if fallbackMedia == nil {
+				fallbackMedia = media
+				fallbackCodec = codec
+			}
+		}
+	}
+
+	if fallbackMedia == nil {
+		return nil, "", fmt.Errorf("aucune piste H.264/H.265 trouvée dans le flux")
+	}
+	return fallbackMedia, fallbackCodec, nil
+}
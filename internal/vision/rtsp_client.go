@@ -0,0 +1,307 @@
+package vision
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"surveillance-core/internal/core"
+)
+
+// StreamCodec identifie le codec vidéo négocié pour un flux.
+type StreamCodec string
+
+const (
+	StreamCodecH264 StreamCodec = "h264"
+	StreamCodecH265 StreamCodec = "h265"
+)
+
+// rtspBackend abstrait la bibliothèque de décodage utilisée pour ouvrir un
+// flux RTSP/RTMP et en extraire des core.Frame décodées. Deux implémentations
+// sont fournies : gortsplibBackend (client RTSP natif Go) et ffmpegBackend
+// (repli basé sur un sous-processus FFmpeg/Joy4 quand gortsplib échoue à
+// négocier le flux, par ex. anciennes caméras RTMP-only).
+type rtspBackend interface {
+	// Open se connecte à l'URL de la caméra et bloque jusqu'à déconnexion,
+	// en poussant chaque frame produite par le backend dans onFrame. Retourne une erreur si
+	// la connexion ou la négociation échoue.
+	Open(ctx context.Context, cameraURL string, opts StreamOptions, onFrame func(core.Frame)) error
+	Codec() StreamCodec
+}
+
+// StreamOptions regroupe les préférences de capture propres à une caméra
+// (transport RTP, codec), alimentées depuis core.CameraConfig. Elles
+// viennent compléter la RTSPClientConfig partagée par tout le client, qui ne
+// peut exprimer que des réglages communs à toutes les caméras.
+type StreamOptions struct {
+	// Transport force le transport RTP ("tcp" ou "udp"). Vide = "tcp".
+	Transport string
+	// PreferredCodec privilégie un codec si la caméra en annonce plusieurs
+	// dans sa description SDP. Vide = premier codec supporté trouvé.
+	PreferredCodec StreamCodec
+}
+
+// RTSPClientConfig configure le client de capture RTSP/RTMP.
+type RTSPClientConfig struct {
+	ReconnectDelay    time.Duration
+	MaxReconnectDelay time.Duration
+	QueueBufferSize   int
+}
+
+// DefaultRTSPClientConfig retourne une configuration par défaut raisonnable.
+func DefaultRTSPClientConfig() RTSPClientConfig {
+	return RTSPClientConfig{
+		ReconnectDelay:    2 * time.Second,
+		MaxReconnectDelay: 30 * time.Second,
+		QueueBufferSize:   10,
+	}
+}
+
+type rtspStream struct {
+	cameraID  string
+	cameraURL string
+	opts      StreamOptions
+	status    StreamStatus
+	queue     *PacketQueue
+	cancel    context.CancelFunc
+	backend   rtspBackend
+	fallback  rtspBackend
+}
+
+// rtspClient implémente Client en capturant des flux RTSP/RTMP réels via un
+// backend de décodage pluggable, avec reconnexion automatique et fan-out des
+// frames décodées à travers un PacketQueue par caméra.
+type rtspClient struct {
+	config     RTSPClientConfig
+	streams    map[string]*rtspStream
+	mutex      sync.RWMutex
+	alertSink  func(core.Alert)
+	newBackend func() (primary rtspBackend, fallback rtspBackend)
+	wg         sync.WaitGroup
+}
+
+// NewRTSPClient crée un client de capture RTSP/RTMP utilisant gortsplib comme
+// backend principal et un repli FFmpeg/Joy4 en cas d'échec de négociation.
+func NewRTSPClient(config RTSPClientConfig) Client {
+	if config.ReconnectDelay <= 0 {
+		config = DefaultRTSPClientConfig()
+	}
+	return &rtspClient{
+		config:  config,
+		streams: make(map[string]*rtspStream),
+		newBackend: func() (rtspBackend, rtspBackend) {
+			return newGortsplibBackend(), newFFmpegBackend()
+		},
+	}
+}
+
+// SetAlertSink enregistre le récepteur d'alertes système (reconnexions,
+// perte de flux). Il est typiquement branché sur EventProcessor.ProcessDetection
+// ou sur le callback WebSocket, de la même façon que main.go câble
+// eventProcessor.SetAlertCallback.
+func (rc *rtspClient) SetAlertSink(sink func(core.Alert)) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	rc.alertSink = sink
+}
+
+func (rc *rtspClient) emitSystemAlert(cameraID, message string, level core.AlertLevel) {
+	rc.mutex.RLock()
+	sink := rc.alertSink
+	rc.mutex.RUnlock()
+
+	if sink == nil {
+		return
+	}
+	sink(core.Alert{
+		CameraID:  cameraID,
+		Type:      core.AlertTypeSystem,
+		Level:     level,
+		Message:   message,
+		Timestamp: time.Now(),
+	})
+}
+
+func (rc *rtspClient) StartStream(ctx context.Context, cameraID string) (<-chan core.Frame, error) {
+	return rc.startStreamForURL(ctx, cameraID, "", StreamOptions{})
+}
+
+// StartStreamWithURL démarre la capture pour une caméra dont l'URL n'est pas
+// déjà connue du client (contrairement au mock, le client RTSP a besoin de
+// l'URL réelle de la caméra pour s'y connecter).
+func (rc *rtspClient) StartStreamWithURL(ctx context.Context, cameraID, cameraURL string) (<-chan core.Frame, error) {
+	return rc.startStreamForURL(ctx, cameraID, cameraURL, StreamOptions{})
+}
+
+// StartStreamWithOptions démarre la capture comme StartStreamWithURL, en
+// appliquant en plus les préférences de transport/codec de opts (voir
+// TransportAwareClient).
+func (rc *rtspClient) StartStreamWithOptions(ctx context.Context, cameraID, cameraURL string, opts StreamOptions) (<-chan core.Frame, error) {
+	return rc.startStreamForURL(ctx, cameraID, cameraURL, opts)
+}
+
+func (rc *rtspClient) startStreamForURL(ctx context.Context, cameraID, cameraURL string, opts StreamOptions) (<-chan core.Frame, error) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	if stream, exists := rc.streams[cameraID]; exists {
+		if stream.status == StreamStatusActive || stream.status == StreamStatusStarting {
+			ch, _ := stream.queue.Subscribe()
+			return ch, nil
+		}
+		rc.stopStreamInternal(cameraID)
+	}
+
+	primary, fallback := rc.newBackend()
+	stream := &rtspStream{
+		cameraID:  cameraID,
+		cameraURL: cameraURL,
+		opts:      opts,
+		status:    StreamStatusStarting,
+		queue:     NewPacketQueue(rc.config.QueueBufferSize),
+		backend:   primary,
+		fallback:  fallback,
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	stream.cancel = cancel
+	rc.streams[cameraID] = stream
+
+	rc.wg.Add(1)
+	go rc.runStream(streamCtx, stream)
+
+	ch, _ := stream.queue.Subscribe()
+	return ch, nil
+}
+
+// runStream maintient la connexion à la caméra, avec backoff exponentiel
+// borné et repli automatique sur le backend secondaire si le backend
+// principal échoue à décoder le flux. Le PacketQueue (et donc les channels
+// des abonnés) n'est fermé qu'en sortie de cette goroutine, qui en est la
+// seule productrice.
+func (rc *rtspClient) runStream(ctx context.Context, stream *rtspStream) {
+	defer rc.wg.Done()
+	defer stream.queue.Close()
+
+	delay := rc.config.ReconnectDelay
+	useFallback := false
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		backend := stream.backend
+		if useFallback {
+			backend = stream.fallback
+		}
+
+		rc.setStatus(stream, StreamStatusStarting)
+		err := backend.Open(ctx, stream.cameraURL, stream.opts, func(frame core.Frame) {
+			frame.CameraID = stream.cameraID
+			rc.setStatus(stream, StreamStatusActive)
+			stream.queue.Publish(frame)
+		})
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err != nil {
+			log.Printf("⚠️ Flux %s (%s) perdu: %v", stream.cameraID, backend.Codec(), err)
+			rc.setStatus(stream, StreamStatusError)
+			rc.emitSystemAlert(stream.cameraID,
+				fmt.Sprintf("perte du flux caméra (%s), reconnexion dans %s", backend.Codec(), delay),
+				core.AlertLevelWarning)
+
+			// Bascule sur le backend de repli après un échec du backend principal.
+			if !useFallback {
+				useFallback = true
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > rc.config.MaxReconnectDelay {
+			delay = rc.config.MaxReconnectDelay
+		}
+	}
+}
+
+func (rc *rtspClient) setStatus(stream *rtspStream, status StreamStatus) {
+	rc.mutex.Lock()
+	stream.status = status
+	rc.mutex.Unlock()
+}
+
+func (rc *rtspClient) StopStream(cameraID string) error {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	return rc.stopStreamInternal(cameraID)
+}
+
+func (rc *rtspClient) stopStreamInternal(cameraID string) error {
+	stream, exists := rc.streams[cameraID]
+	if !exists {
+		return fmt.Errorf("stream non trouvé pour caméra: %s", cameraID)
+	}
+
+	stream.cancel()
+	delete(rc.streams, cameraID)
+
+	return nil
+}
+
+// Close annule tous les flux actifs et attend que leurs goroutines runStream
+// se terminent (queue fermée comprise), ou que ctx expire.
+func (rc *rtspClient) Close(ctx context.Context) error {
+	rc.mutex.Lock()
+	for cameraID := range rc.streams {
+		rc.stopStreamInternal(cameraID)
+	}
+	rc.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		rc.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("arrêt des flux RTSP non terminé avant expiration du délai: %w", ctx.Err())
+	}
+}
+
+func (rc *rtspClient) GetStreamStatus(cameraID string) StreamStatus {
+	rc.mutex.RLock()
+	defer rc.mutex.RUnlock()
+
+	if stream, exists := rc.streams[cameraID]; exists {
+		return stream.status
+	}
+	return StreamStatusStopped
+}
+
+func (rc *rtspClient) IsConnected() bool {
+	rc.mutex.RLock()
+	defer rc.mutex.RUnlock()
+
+	for _, stream := range rc.streams {
+		if stream.status == StreamStatusActive {
+			return true
+		}
+	}
+	return false
+}
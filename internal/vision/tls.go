@@ -0,0 +1,50 @@
+// internal/vision/tls.go
+package vision
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"surveillance-core/internal/core"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// credentialsFromConfig traduit un core.TLSCfg en credentials.TransportCredentials
+// pour le ClientConn gRPC du service vision, sur le même modèle que
+// internal/notify/tls.go pour les canaux HTTP/SMTP. cfg.Enabled == false
+// retourne (nil, nil) : l'appelant retombe alors sur insecure.NewCredentials().
+func credentialsFromConfig(cfg core.TLSCfg) (credentials.TransportCredentials, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         cfg.ServerName,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CertFile != "" && cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("chargement du certificat client %s: %w", cfg.CertFile, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("lecture de la CA %s: %w", cfg.CAFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA %s: aucun certificat PEM valide", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
@@ -18,37 +18,69 @@ var upgrader = websocket.Upgrader{
 	},
 }
 
+// defaultReplayBufferSize est la capacité du ring-buffer de replay par topic
+// utilisée quand NewHub reçoit une capacité <= 0.
+const defaultReplayBufferSize = 200
+
 type Hub struct {
-	clients    map[*Client]bool
-	broadcast  chan Message
-	register   chan *Client
-	unregister chan *Client
-	mutex      sync.RWMutex
+	clients        map[*Client]bool
+	broadcast      chan Message
+	broadcastTopic chan topicMessage
+	register       chan *Client
+	unregister     chan *Client
+	mutex          sync.RWMutex
+
+	replayMutex    sync.Mutex
+	replayBuffers  map[string][]Message
+	replayCapacity int
+	nextSeq        int64
 }
 
 type Client struct {
-	hub  *Hub
-	conn *websocket.Conn
-	send chan Message
-	id   string
+	hub    *Hub
+	conn   *websocket.Conn
+	send   chan Message
+	id     string
+	topics map[string]bool
+	mutex  sync.RWMutex
 }
 
 type Message struct {
 	Type      string      `json:"type"`
 	Data      interface{} `json:"data"`
 	Timestamp time.Time   `json:"timestamp"`
+	// Seq est un numéro de séquence monotone attribué par Hub.BroadcastTo,
+	// permettant à un client de reprendre le replay exactement là où il
+	// s'est arrêté (voir Hub.ReplaySince).
+	Seq int64 `json:"seq"`
+}
+
+// topicMessage associe un Message au topic sur lequel BroadcastTo doit le
+// diffuser (ex. "camera:cam_001", "alerts:critical").
+type topicMessage struct {
+	topic   string
+	message Message
 }
 
 type Handler struct {
 	hub *Hub
 }
 
-func NewHub() *Hub {
+// NewHub crée un Hub dont le ring-buffer de replay par topic retient jusqu'à
+// replayBufferSize messages (voir Hub.ReplaySince). Une capacité <= 0 retombe
+// sur defaultReplayBufferSize.
+func NewHub(replayBufferSize int) *Hub {
+	if replayBufferSize <= 0 {
+		replayBufferSize = defaultReplayBufferSize
+	}
 	return &Hub{
-		clients:    make(map[*Client]bool),
-		broadcast:  make(chan Message, 256),
-		register:   make(chan *Client),
-		unregister: make(chan *Client),
+		clients:        make(map[*Client]bool),
+		broadcast:      make(chan Message, 256),
+		broadcastTopic: make(chan topicMessage, 256),
+		register:       make(chan *Client),
+		unregister:     make(chan *Client),
+		replayBuffers:  make(map[string][]Message),
+		replayCapacity: replayBufferSize,
 	}
 }
 
@@ -94,6 +126,23 @@ func (h *Hub) Run() {
 				}
 			}
 			h.mutex.RUnlock()
+
+		case tm := <-h.broadcastTopic:
+			tm.message.Timestamp = time.Now()
+			h.mutex.RLock()
+			for client := range h.clients {
+				if !client.isSubscribed(tm.topic) {
+					continue
+				}
+				select {
+				case client.send <- tm.message:
+				default:
+					h.mutex.RUnlock()
+					h.removeClient(client)
+					h.mutex.RLock()
+				}
+			}
+			h.mutex.RUnlock()
 		}
 	}
 }
@@ -118,6 +167,58 @@ func (h *Hub) Broadcast(message Message) {
 	}
 }
 
+// BroadcastTo ne diffuse message qu'aux clients abonnés à topic (voir
+// Client.Subscribe), plutôt qu'à tous les clients connectés comme
+// Broadcast. Utilisé pour les topics scopés par caméra ("camera:cam_001")
+// ou par sévérité d'alerte ("alerts:critical"). message.Seq est écrasé par un
+// numéro de séquence monotone et message est ajouté au ring-buffer de
+// replay du topic, pour qu'un abonné tardif puisse le rattraper via
+// ReplaySince.
+func (h *Hub) BroadcastTo(topic string, message Message) {
+	message.Seq = h.appendToReplayBuffer(topic, message)
+
+	select {
+	case h.broadcastTopic <- topicMessage{topic: topic, message: message}:
+	default:
+		log.Println("Canal broadcast (topic) plein, message abandonné")
+	}
+}
+
+// appendToReplayBuffer attribue le prochain numéro de séquence et ajoute
+// message au ring-buffer de topic, borné à replayCapacity entrées.
+func (h *Hub) appendToReplayBuffer(topic string, message Message) int64 {
+	h.replayMutex.Lock()
+	defer h.replayMutex.Unlock()
+
+	h.nextSeq++
+	message.Seq = h.nextSeq
+
+	buf := append(h.replayBuffers[topic], message)
+	if len(buf) > h.replayCapacity {
+		buf = buf[len(buf)-h.replayCapacity:]
+	}
+	h.replayBuffers[topic] = buf
+
+	return message.Seq
+}
+
+// ReplaySince retourne, dans l'ordre chronologique, les messages du
+// ring-buffer de topic dont le Seq est strictement supérieur à since. Un
+// since de 0 retourne donc tout le buffer retenu.
+func (h *Hub) ReplaySince(topic string, since int64) []Message {
+	h.replayMutex.Lock()
+	defer h.replayMutex.Unlock()
+
+	buffered := h.replayBuffers[topic]
+	result := make([]Message, 0, len(buffered))
+	for _, message := range buffered {
+		if message.Seq > since {
+			result = append(result, message)
+		}
+	}
+	return result
+}
+
 func (h *Hub) GetClientCount() int {
 	h.mutex.RLock()
 	defer h.mutex.RUnlock()
@@ -137,10 +238,11 @@ func (handler *Handler) HandleWebSocket(w http.ResponseWriter, r *http.Request)
 	}
 
 	client := &Client{
-		hub:  handler.hub,
-		conn: conn,
-		send: make(chan Message, 256),
-		id:   clientID,
+		hub:    handler.hub,
+		conn:   conn,
+		send:   make(chan Message, 256),
+		id:     clientID,
+		topics: make(map[string]bool),
 	}
 
 	client.hub.register <- client
@@ -185,11 +287,84 @@ func (c *Client) readPump() {
 			continue
 		}
 
-		// Echo pour test ou traitement spécifique
-		log.Printf("Message reçu de %s: %s", c.id, msg.Type)
+		switch msg.Type {
+		case "subscribe":
+			topic, since, ok := parseSubscribePayload(msg.Data)
+			if !ok {
+				log.Printf("Message subscribe invalide de %s: %v", c.id, msg.Data)
+				continue
+			}
+
+			// Abonner le client en live avant de rejouer le buffer, pour ne
+			// pas lui faire rater un message publié entre les deux (voir
+			// Hub.ReplaySince) : un tel message peut alors être reçu deux fois,
+			// avec le même Seq, et un message live peut arriver avant la fin
+			// du replay.
+			c.Subscribe(topic)
+			for _, replayed := range c.hub.ReplaySince(topic, since) {
+				select {
+				case c.send <- replayed:
+				default:
+				}
+			}
+			log.Printf("Client %s abonné à %s (depuis seq %d)", c.id, topic, since)
+		case "unsubscribe":
+			if topic, ok := msg.Data.(string); ok {
+				c.Unsubscribe(topic)
+				log.Printf("Client %s désabonné de %s", c.id, topic)
+			}
+		default:
+			// Echo pour test ou traitement spécifique
+			log.Printf("Message reçu de %s: %s", c.id, msg.Type)
+		}
 	}
 }
 
+// parseSubscribePayload extrait le topic et le curseur de reprise ("since")
+// d'un message subscribe. data peut être soit une simple chaîne (topic seul,
+// replay complet du buffer retenu), soit un objet {"topic": ..., "since": ...}
+// quand le client veut reprendre après un Seq précis.
+func parseSubscribePayload(data interface{}) (topic string, since int64, ok bool) {
+	switch v := data.(type) {
+	case string:
+		return v, 0, v != ""
+	case map[string]interface{}:
+		topic, ok = v["topic"].(string)
+		if !ok || topic == "" {
+			return "", 0, false
+		}
+		if sinceVal, present := v["since"]; present {
+			if sinceFloat, isNum := sinceVal.(float64); isNum {
+				since = int64(sinceFloat)
+			}
+		}
+		return topic, since, true
+	default:
+		return "", 0, false
+	}
+}
+
+// Subscribe abonne le client à topic : il recevra désormais les messages
+// publiés dessus via Hub.BroadcastTo.
+func (c *Client) Subscribe(topic string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.topics[topic] = true
+}
+
+// Unsubscribe désabonne le client de topic.
+func (c *Client) Unsubscribe(topic string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.topics, topic)
+}
+
+func (c *Client) isSubscribed(topic string) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.topics[topic]
+}
+
 func (c *Client) writePump() {
 	ticker := time.NewTicker(pingPeriod)
 	defer func() {
@@ -0,0 +1,63 @@
+// internal/websocket/hub_test.go
+package websocket
+
+import "testing"
+
+func TestHub_ReplaySince_OutOfOrderAndLiveBoundary(t *testing.T) {
+	hub := NewHub(2)
+
+	hub.BroadcastTo("camera:cam_001", Message{Type: "frame", Data: "first"})
+	hub.BroadcastTo("camera:cam_001", Message{Type: "frame", Data: "second"})
+	hub.BroadcastTo("camera:cam_001", Message{Type: "frame", Data: "third"})
+
+	// Capacité 2 : "first" doit avoir été évincé du ring-buffer.
+	all := hub.ReplaySince("camera:cam_001", 0)
+	if len(all) != 2 {
+		t.Fatalf("Expected 2 buffered messages, got %d", len(all))
+	}
+	if all[0].Data != "second" || all[1].Data != "third" {
+		t.Errorf("Expected [second, third] in order, got [%v, %v]", all[0].Data, all[1].Data)
+	}
+	if all[0].Seq >= all[1].Seq {
+		t.Errorf("Expected monotonically increasing Seq, got %d then %d", all[0].Seq, all[1].Seq)
+	}
+
+	// Un client qui reprend depuis le Seq de "second" ne doit voir que ce qui
+	// a été publié après : c'est la frontière replay/live.
+	sinceSecond := hub.ReplaySince("camera:cam_001", all[0].Seq)
+	if len(sinceSecond) != 1 || sinceSecond[0].Data != "third" {
+		t.Fatalf("Expected only [third] after second's Seq, got %v", sinceSecond)
+	}
+
+	// Un autre topic n'a pas accès au buffer de camera:cam_001.
+	if other := hub.ReplaySince("camera:cam_002", 0); len(other) != 0 {
+		t.Errorf("Expected empty replay buffer for unrelated topic, got %d messages", len(other))
+	}
+}
+
+func TestParseSubscribePayload(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        interface{}
+		expectTopic string
+		expectSince int64
+		expectOk    bool
+	}{
+		{"plain string topic", "camera:cam_001", "camera:cam_001", 0, true},
+		{"empty string topic", "", "", 0, false},
+		{"object with since", map[string]interface{}{"topic": "alerts:critical", "since": float64(42)}, "alerts:critical", 42, true},
+		{"object without since", map[string]interface{}{"topic": "alerts:critical"}, "alerts:critical", 0, true},
+		{"object missing topic", map[string]interface{}{"since": float64(1)}, "", 0, false},
+		{"unsupported type", 123, "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			topic, since, ok := parseSubscribePayload(tt.data)
+			if ok != tt.expectOk || topic != tt.expectTopic || since != tt.expectSince {
+				t.Errorf("parseSubscribePayload(%v) = (%q, %d, %v), want (%q, %d, %v)",
+					tt.data, topic, since, ok, tt.expectTopic, tt.expectSince, tt.expectOk)
+			}
+		})
+	}
+}
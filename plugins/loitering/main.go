@@ -0,0 +1,102 @@
+// Package main implémente un détecteur de flânage ("loitering") chargé
+// dynamiquement par core.PluginLoader via le package standard "plugin".
+//
+// Build : go build -buildmode=plugin -o plugins/loitering.so ./plugins/loitering
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"surveillance-core/internal/core"
+)
+
+// PluginABIVersion est vérifié par le loader avant instanciation.
+var PluginABIVersion = core.PluginABIVersion
+
+// loiterThreshold est la durée de présence continue d'une personne sur une
+// caméra au-delà de laquelle une alerte de flânage est déclenchée.
+const loiterThreshold = 30 * time.Second
+
+// NewDetector est le point d'entrée attendu par le loader.
+func NewDetector() core.Detector {
+	return &loiteringDetector{}
+}
+
+// loiteringDetector garde, par caméra, le moment de la première détection
+// "person" d'une séquence continue. C'est l'exemple typique d'un détecteur
+// avec état que l'interface core.Detector de base ne peut pas exprimer, d'où
+// l'implémentation de core.StatefulDetector.
+type loiteringDetector struct {
+	mutex     sync.Mutex
+	firstSeen map[string]time.Time
+	lastSeen  map[string]time.Time
+}
+
+func (ld *loiteringDetector) Init(ctx context.Context) error {
+	ld.mutex.Lock()
+	defer ld.mutex.Unlock()
+
+	ld.firstSeen = make(map[string]time.Time)
+	ld.lastSeen = make(map[string]time.Time)
+	return nil
+}
+
+func (ld *loiteringDetector) Close() error {
+	ld.mutex.Lock()
+	defer ld.mutex.Unlock()
+
+	ld.firstSeen = nil
+	ld.lastSeen = nil
+	return nil
+}
+
+func (ld *loiteringDetector) ShouldAlert(detection core.Detection) bool {
+	if detection.Type != core.DetectionTypePerson {
+		ld.forgetCamera(detection.CameraID)
+		return false
+	}
+
+	ld.mutex.Lock()
+	defer ld.mutex.Unlock()
+
+	now := detection.Timestamp
+	first, tracked := ld.firstSeen[detection.CameraID]
+
+	// Une absence de plus de 2x le seuil entre deux détections "person"
+	// réinitialise la séquence (ce n'est plus la même présence continue).
+	if tracked && now.Sub(ld.lastSeen[detection.CameraID]) > 2*loiterThreshold {
+		tracked = false
+	}
+
+	if !tracked {
+		ld.firstSeen[detection.CameraID] = now
+		first = now
+	}
+	ld.lastSeen[detection.CameraID] = now
+
+	return now.Sub(first) >= loiterThreshold
+}
+
+func (ld *loiteringDetector) CreateAlert(detection core.Detection) core.Alert {
+	ld.mutex.Lock()
+	duration := detection.Timestamp.Sub(ld.firstSeen[detection.CameraID])
+	ld.mutex.Unlock()
+
+	return core.Alert{
+		CameraID:  detection.CameraID,
+		Type:      core.AlertTypeIntrusion,
+		Level:     core.AlertLevelWarning,
+		Message:   fmt.Sprintf("Flânage détecté (présence continue depuis %s)", duration.Round(time.Second)),
+		Detection: &detection,
+	}
+}
+
+func (ld *loiteringDetector) forgetCamera(cameraID string) {
+	ld.mutex.Lock()
+	defer ld.mutex.Unlock()
+	delete(ld.firstSeen, cameraID)
+	delete(ld.lastSeen, cameraID)
+}